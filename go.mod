@@ -5,6 +5,7 @@ go 1.24.9
 toolchain go1.24.11
 
 require (
+	github.com/andybalholm/brotli v1.1.1
 	github.com/kaitai-io/kaitai_struct_go_runtime v0.11.0
 	github.com/klauspost/compress v1.17.9
 )
@@ -142,7 +142,7 @@ func (this *ThriftCompact_CompactField) Read(io *kaitai.Stream, parent *ThriftCo
 		if err != nil {
 			return err
 		}
-		tmp8 := NewThriftCompact_CompactValue(uint8(tmp7))
+		tmp8 := NewThriftCompact_CompactValue(uint8(tmp7), false)
 		err = tmp8.Read(this._io, this, this._root)
 		if err != nil {
 			return err
@@ -305,7 +305,7 @@ func (this *ThriftCompact_CompactList) Read(io *kaitai.Stream, parent *ThriftCom
 		if err != nil {
 			return err
 		}
-		tmp19 := NewThriftCompact_CompactValue(uint8(tmp18))
+		tmp19 := NewThriftCompact_CompactValue(uint8(tmp18), true)
 		err = tmp19.Read(this._io, this, this._root)
 		if err != nil {
 			return err
@@ -765,6 +765,7 @@ func (this *ThriftCompact_CompactStruct) Read(io *kaitai.Stream, parent kaitai.S
  * Value of the field, type depends on value_type parameter
  */
 type ThriftCompact_CompactValue struct {
+	BoolValue uint8
 	ByteValue int8
 	I16Value *ThriftCompact_VarintZ
 	I32Value *ThriftCompact_VarintZ
@@ -776,13 +777,15 @@ type ThriftCompact_CompactValue struct {
 	MapValue *ThriftCompact_CompactMap
 	StructValue *ThriftCompact_CompactStruct
 	ValueType uint8
+	IsElement bool
 	_io *kaitai.Stream
 	_root *ThriftCompact
 	_parent kaitai.Struct
 }
-func NewThriftCompact_CompactValue(valueType uint8) *ThriftCompact_CompactValue {
+func NewThriftCompact_CompactValue(valueType uint8, isElement bool) *ThriftCompact_CompactValue {
 	return &ThriftCompact_CompactValue{
 		ValueType: valueType,
+		IsElement: isElement,
 	}
 }
 
@@ -795,86 +798,102 @@ func (this *ThriftCompact_CompactValue) Read(io *kaitai.Stream, parent kaitai.St
 	this._parent = parent
 	this._root = root
 
-	if (this.ValueType == 3) {
-		tmp48, err := this._io.ReadS1()
+	if ( ((this.IsElement) && ( ((this.ValueType == 1) || (this.ValueType == 2)) )) ) {
+		tmp48, err := this._io.ReadU1()
 		if err != nil {
 			return err
 		}
-		this.ByteValue = tmp48
+		this.BoolValue = tmp48
 	}
-	if (this.ValueType == 4) {
-		tmp49 := NewThriftCompact_VarintZ()
-		err = tmp49.Read(this._io, this, this._root)
+	if (this.ValueType == 3) {
+		tmp49, err := this._io.ReadS1()
 		if err != nil {
 			return err
 		}
-		this.I16Value = tmp49
+		this.ByteValue = tmp49
 	}
-	if (this.ValueType == 5) {
+	if (this.ValueType == 4) {
 		tmp50 := NewThriftCompact_VarintZ()
 		err = tmp50.Read(this._io, this, this._root)
 		if err != nil {
 			return err
 		}
-		this.I32Value = tmp50
+		this.I16Value = tmp50
 	}
-	if (this.ValueType == 6) {
+	if (this.ValueType == 5) {
 		tmp51 := NewThriftCompact_VarintZ()
 		err = tmp51.Read(this._io, this, this._root)
 		if err != nil {
 			return err
 		}
-		this.I64Value = tmp51
+		this.I32Value = tmp51
+	}
+	if (this.ValueType == 6) {
+		tmp52 := NewThriftCompact_VarintZ()
+		err = tmp52.Read(this._io, this, this._root)
+		if err != nil {
+			return err
+		}
+		this.I64Value = tmp52
 	}
 	if (this.ValueType == 7) {
-		tmp52, err := this._io.ReadF8le()
+		tmp53, err := this._io.ReadF8le()
 		if err != nil {
 			return err
 		}
-		this.DoubleValue = float64(tmp52)
+		this.DoubleValue = float64(tmp53)
 	}
 	if (this.ValueType == 8) {
-		tmp53 := NewThriftCompact_CompactString()
-		err = tmp53.Read(this._io, this, this._root)
+		tmp54 := NewThriftCompact_CompactString()
+		err = tmp54.Read(this._io, this, this._root)
 		if err != nil {
 			return err
 		}
-		this.BinaryValue = tmp53
+		this.BinaryValue = tmp54
 	}
 	if (this.ValueType == 9) {
-		tmp54 := NewThriftCompact_CompactList()
-		err = tmp54.Read(this._io, this, this._root)
+		tmp55 := NewThriftCompact_CompactList()
+		err = tmp55.Read(this._io, this, this._root)
 		if err != nil {
 			return err
 		}
-		this.ListValue = tmp54
+		this.ListValue = tmp55
 	}
 	if (this.ValueType == 10) {
-		tmp55 := NewThriftCompact_CompactList()
-		err = tmp55.Read(this._io, this, this._root)
+		tmp56 := NewThriftCompact_CompactList()
+		err = tmp56.Read(this._io, this, this._root)
 		if err != nil {
 			return err
 		}
-		this.SetValue = tmp55
+		this.SetValue = tmp56
 	}
 	if (this.ValueType == 11) {
-		tmp56 := NewThriftCompact_CompactMap()
-		err = tmp56.Read(this._io, this, this._root)
+		tmp57 := NewThriftCompact_CompactMap()
+		err = tmp57.Read(this._io, this, this._root)
 		if err != nil {
 			return err
 		}
-		this.MapValue = tmp56
+		this.MapValue = tmp57
 	}
 	if (this.ValueType == 12) {
-		tmp57 := NewThriftCompact_CompactStruct()
-		err = tmp57.Read(this._io, this, this._root)
+		tmp58 := NewThriftCompact_CompactStruct()
+		err = tmp58.Read(this._io, this, this._root)
 		if err != nil {
 			return err
 		}
-		this.StructValue = tmp57
+		this.StructValue = tmp58
 	}
 	return err
 }
+
+/**
+ * Bool element (1 = true; writers use 2 or 0 for false)
+ */
+
+/**
+ * True for list, set and map elements. Struct fields carry bools in
+ * the field type nibble, while elements store them as one byte each.
+ */
 type ThriftCompact_MapEntry struct {
 	Key *ThriftCompact_CompactValue
 	Value *ThriftCompact_CompactValue
@@ -900,18 +919,18 @@ func (this *ThriftCompact_MapEntry) Read(io *kaitai.Stream, parent *ThriftCompac
 	this._parent = parent
 	this._root = root
 
-	tmp58 := NewThriftCompact_CompactValue(this.KeyType)
-	err = tmp58.Read(this._io, this, this._root)
+	tmp59 := NewThriftCompact_CompactValue(this.KeyType, true)
+	err = tmp59.Read(this._io, this, this._root)
 	if err != nil {
 		return err
 	}
-	this.Key = tmp58
-	tmp59 := NewThriftCompact_CompactValue(this.ValType)
-	err = tmp59.Read(this._io, this, this._root)
+	this.Key = tmp59
+	tmp60 := NewThriftCompact_CompactValue(this.ValType, true)
+	err = tmp60.Read(this._io, this, this._root)
 	if err != nil {
 		return err
 	}
-	this.Value = tmp59
+	this.Value = tmp60
 	return err
 }
 
@@ -945,11 +964,11 @@ func (this *ThriftCompact_VarintU) Read(io *kaitai.Stream, parent kaitai.Struct,
 	this._root = root
 
 	for i := 1;; i++ {
-		tmp60, err := this._io.ReadU1()
+		tmp61, err := this._io.ReadU1()
 		if err != nil {
 			return err
 		}
-		_it := tmp60
+		_it := tmp61
 		this.Bytes = append(this.Bytes, _it)
 		if _it & 128 == 0 {
 			break
@@ -966,61 +985,61 @@ func (this *ThriftCompact_VarintU) ValueU() (v int, err error) {
 		return this.valueU, nil
 	}
 	this._f_valueU = true
-	var tmp61 int;
-	if (len(this.Bytes) > 1) {
-		tmp61 = int(this.Bytes[1] & 127) << 7
-	} else {
-		tmp61 = 0
-	}
 	var tmp62 int;
-	if (len(this.Bytes) > 2) {
-		tmp62 = int(this.Bytes[2] & 127) << 14
+	if (len(this.Bytes) > 1) {
+		tmp62 = int(this.Bytes[1] & 127) << 7
 	} else {
 		tmp62 = 0
 	}
 	var tmp63 int;
-	if (len(this.Bytes) > 3) {
-		tmp63 = int(this.Bytes[3] & 127) << 21
+	if (len(this.Bytes) > 2) {
+		tmp63 = int(this.Bytes[2] & 127) << 14
 	} else {
 		tmp63 = 0
 	}
 	var tmp64 int;
-	if (len(this.Bytes) > 4) {
-		tmp64 = int(this.Bytes[4] & 127) << 28
+	if (len(this.Bytes) > 3) {
+		tmp64 = int(this.Bytes[3] & 127) << 21
 	} else {
 		tmp64 = 0
 	}
 	var tmp65 int;
-	if (len(this.Bytes) > 5) {
-		tmp65 = int(this.Bytes[5] & 127) << 35
+	if (len(this.Bytes) > 4) {
+		tmp65 = int(this.Bytes[4] & 127) << 28
 	} else {
 		tmp65 = 0
 	}
 	var tmp66 int;
-	if (len(this.Bytes) > 6) {
-		tmp66 = int(this.Bytes[6] & 127) << 42
+	if (len(this.Bytes) > 5) {
+		tmp66 = int(this.Bytes[5] & 127) << 35
 	} else {
 		tmp66 = 0
 	}
 	var tmp67 int;
-	if (len(this.Bytes) > 7) {
-		tmp67 = int(this.Bytes[7] & 127) << 49
+	if (len(this.Bytes) > 6) {
+		tmp67 = int(this.Bytes[6] & 127) << 42
 	} else {
 		tmp67 = 0
 	}
 	var tmp68 int;
-	if (len(this.Bytes) > 8) {
-		tmp68 = int(this.Bytes[8] & 127) << 56
+	if (len(this.Bytes) > 7) {
+		tmp68 = int(this.Bytes[7] & 127) << 49
 	} else {
 		tmp68 = 0
 	}
 	var tmp69 int;
-	if (len(this.Bytes) > 9) {
-		tmp69 = int(this.Bytes[9] & 127) << 63
+	if (len(this.Bytes) > 8) {
+		tmp69 = int(this.Bytes[8] & 127) << 56
 	} else {
 		tmp69 = 0
 	}
-	this.valueU = int(((((((((int(this.Bytes[0] & 127) + tmp61) + tmp62) + tmp63) + tmp64) + tmp65) + tmp66) + tmp67) + tmp68) + tmp69)
+	var tmp70 int;
+	if (len(this.Bytes) > 9) {
+		tmp70 = int(this.Bytes[9] & 127) << 63
+	} else {
+		tmp70 = 0
+	}
+	this.valueU = int(((((((((int(this.Bytes[0] & 127) + tmp62) + tmp63) + tmp64) + tmp65) + tmp66) + tmp67) + tmp68) + tmp69) + tmp70)
 	return this.valueU, nil
 }
 
@@ -1053,11 +1072,11 @@ func (this *ThriftCompact_VarintZ) Read(io *kaitai.Stream, parent kaitai.Struct,
 	this._root = root
 
 	for i := 1;; i++ {
-		tmp70, err := this._io.ReadU1()
+		tmp71, err := this._io.ReadU1()
 		if err != nil {
 			return err
 		}
-		_it := tmp70
+		_it := tmp71
 		this.Bytes = append(this.Bytes, _it)
 		if _it & 128 == 0 {
 			break
@@ -1074,15 +1093,15 @@ func (this *ThriftCompact_VarintZ) Value() (v int, err error) {
 		return this.value, nil
 	}
 	this._f_value = true
-	tmp71, err := this.ValueU()
+	tmp72, err := this.ValueU()
 	if err != nil {
 		return 0, err
 	}
-	tmp72, err := this.ValueU()
+	tmp73, err := this.ValueU()
 	if err != nil {
 		return 0, err
 	}
-	this.value = int(tmp71 >> 1 ^ -(tmp72 & 1))
+	this.value = int(tmp72 >> 1 ^ -(tmp73 & 1))
 	return this.value, nil
 }
 
@@ -1094,61 +1113,61 @@ func (this *ThriftCompact_VarintZ) ValueU() (v int, err error) {
 		return this.valueU, nil
 	}
 	this._f_valueU = true
-	var tmp73 int;
-	if (len(this.Bytes) > 1) {
-		tmp73 = int(this.Bytes[1] & 127) << 7
-	} else {
-		tmp73 = 0
-	}
 	var tmp74 int;
-	if (len(this.Bytes) > 2) {
-		tmp74 = int(this.Bytes[2] & 127) << 14
+	if (len(this.Bytes) > 1) {
+		tmp74 = int(this.Bytes[1] & 127) << 7
 	} else {
 		tmp74 = 0
 	}
 	var tmp75 int;
-	if (len(this.Bytes) > 3) {
-		tmp75 = int(this.Bytes[3] & 127) << 21
+	if (len(this.Bytes) > 2) {
+		tmp75 = int(this.Bytes[2] & 127) << 14
 	} else {
 		tmp75 = 0
 	}
 	var tmp76 int;
-	if (len(this.Bytes) > 4) {
-		tmp76 = int(this.Bytes[4] & 127) << 28
+	if (len(this.Bytes) > 3) {
+		tmp76 = int(this.Bytes[3] & 127) << 21
 	} else {
 		tmp76 = 0
 	}
 	var tmp77 int;
-	if (len(this.Bytes) > 5) {
-		tmp77 = int(this.Bytes[5] & 127) << 35
+	if (len(this.Bytes) > 4) {
+		tmp77 = int(this.Bytes[4] & 127) << 28
 	} else {
 		tmp77 = 0
 	}
 	var tmp78 int;
-	if (len(this.Bytes) > 6) {
-		tmp78 = int(this.Bytes[6] & 127) << 42
+	if (len(this.Bytes) > 5) {
+		tmp78 = int(this.Bytes[5] & 127) << 35
 	} else {
 		tmp78 = 0
 	}
 	var tmp79 int;
-	if (len(this.Bytes) > 7) {
-		tmp79 = int(this.Bytes[7] & 127) << 49
+	if (len(this.Bytes) > 6) {
+		tmp79 = int(this.Bytes[6] & 127) << 42
 	} else {
 		tmp79 = 0
 	}
 	var tmp80 int;
-	if (len(this.Bytes) > 8) {
-		tmp80 = int(this.Bytes[8] & 127) << 56
+	if (len(this.Bytes) > 7) {
+		tmp80 = int(this.Bytes[7] & 127) << 49
 	} else {
 		tmp80 = 0
 	}
 	var tmp81 int;
-	if (len(this.Bytes) > 9) {
-		tmp81 = int(this.Bytes[9] & 127) << 63
+	if (len(this.Bytes) > 8) {
+		tmp81 = int(this.Bytes[8] & 127) << 56
 	} else {
 		tmp81 = 0
 	}
-	this.valueU = int(((((((((int(this.Bytes[0] & 127) + tmp73) + tmp74) + tmp75) + tmp76) + tmp77) + tmp78) + tmp79) + tmp80) + tmp81)
+	var tmp82 int;
+	if (len(this.Bytes) > 9) {
+		tmp82 = int(this.Bytes[9] & 127) << 63
+	} else {
+		tmp82 = 0
+	}
+	this.valueU = int(((((((((int(this.Bytes[0] & 127) + tmp74) + tmp75) + tmp76) + tmp77) + tmp78) + tmp79) + tmp80) + tmp81) + tmp82)
 	return this.valueU, nil
 }
 
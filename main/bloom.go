@@ -0,0 +1,144 @@
+package main
+
+import (
+	"encoding/hex"
+	"fmt"
+	"math/big"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"kaitai_parquet/parquet"
+)
+
+// runBloom looks up a value in the bloom filters of one column and prints,
+// for each row group, whether the value may be present.
+func runBloom(filePath, column, text string, checksumMode parquet.ChecksumMode) error {
+	file, err := os.Open(filePath)
+	if err != nil {
+		return fmt.Errorf("error opening file: %v", err)
+	}
+	defer file.Close()
+
+	stat, err := file.Stat()
+	if err != nil {
+		return fmt.Errorf("error reading file info: %v", err)
+	}
+
+	pf, err := parquet.OpenFile(file, stat.Size(),
+		parquet.WithChecksumMode(checksumMode),
+		parquet.WithWarningHandler(func(err error) {
+			fmt.Fprintf(os.Stderr, "Warning: %v\n", err)
+		}),
+	)
+	if err != nil {
+		return err
+	}
+
+	var leaf *parquet.SchemaNode
+	for _, l := range pf.Leaves() {
+		if l.DottedPath() == column {
+			leaf = l
+			break
+		}
+	}
+	if leaf == nil {
+		return fmt.Errorf("no leaf column %q", column)
+	}
+
+	value, err := parseValue(leaf.Element, text)
+	if err != nil {
+		return fmt.Errorf("parsing %q for column %s: %v", text, column, err)
+	}
+
+	for i := range pf.RowGroups() {
+		col, err := pf.ColumnChunk(i, leaf.ColumnIndex)
+		if err != nil {
+			return err
+		}
+		bloom, err := col.BloomFilter()
+		if err != nil {
+			return fmt.Errorf("row group %d: %v", i, err)
+		}
+		if bloom == nil {
+			fmt.Printf("Row group %d: no bloom filter\n", i)
+			continue
+		}
+		found, err := bloom.MightContain(value)
+		if err != nil {
+			return fmt.Errorf("row group %d: %v", i, err)
+		}
+		if found {
+			fmt.Printf("Row group %d: might contain %s\n", i, text)
+		} else {
+			fmt.Printf("Row group %d: does not contain %s\n", i, text)
+		}
+	}
+	return nil
+}
+
+// parseValue parses a command-line value into the logical value type of a
+// column: dates as 2006-01-02, times as 15:04:05.999999999, timestamps as
+// RFC 3339, decimals and numbers in decimal notation and UUIDs in their
+// canonical form. Byte arrays without a logical type take the text as is.
+func parseValue(elem parquet.SchemaElement, text string) (interface{}, error) {
+	if lt := elem.ResolvedLogicalType(); lt != nil {
+		switch lt.Kind {
+		case parquet.LogicalTypeDecimal:
+			if lt.Decimal == nil {
+				break
+			}
+			r, ok := new(big.Rat).SetString(text)
+			if !ok {
+				return nil, fmt.Errorf("not a decimal number")
+			}
+			r.Mul(r, new(big.Rat).SetInt(new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(lt.Decimal.Scale)), nil)))
+			if !r.IsInt() {
+				return nil, fmt.Errorf("more than %d decimal places", lt.Decimal.Scale)
+			}
+			return parquet.Decimal{Unscaled: r.Num(), Scale: lt.Decimal.Scale}, nil
+		case parquet.LogicalTypeDate:
+			t, err := time.Parse("2006-01-02", text)
+			if err != nil {
+				return nil, err
+			}
+			return parquet.Date{Year: t.Year(), Month: t.Month(), Day: t.Day()}, nil
+		case parquet.LogicalTypeTime:
+			t, err := time.Parse("15:04:05.999999999", text)
+			if err != nil {
+				return nil, err
+			}
+			return parquet.TimeOfDay(t.Sub(time.Date(0, 1, 1, 0, 0, 0, 0, time.UTC))), nil
+		case parquet.LogicalTypeTimestamp:
+			return time.Parse(time.RFC3339Nano, text)
+		case parquet.LogicalTypeUUID:
+			b, err := hex.DecodeString(strings.ReplaceAll(text, "-", ""))
+			if err != nil || len(b) != 16 {
+				return nil, fmt.Errorf("not a UUID")
+			}
+			var u parquet.UUID
+			copy(u[:], b)
+			return u, nil
+		case parquet.LogicalTypeInteger:
+			if lt.Integer != nil && !lt.Integer.IsSigned {
+				return strconv.ParseUint(text, 10, 64)
+			}
+		}
+	}
+
+	switch elem.Type {
+	case 0: // BOOLEAN
+		return strconv.ParseBool(text)
+	case 1, 2: // INT32, INT64
+		return strconv.ParseInt(text, 10, 64)
+	case 3: // INT96
+		return time.Parse(time.RFC3339Nano, text)
+	case 4: // FLOAT
+		f, err := strconv.ParseFloat(text, 32)
+		return float32(f), err
+	case 5: // DOUBLE
+		return strconv.ParseFloat(text, 64)
+	}
+	return text, nil
+}
@@ -2,34 +2,72 @@ package main
 
 import (
 	"context"
+	"flag"
 	"fmt"
 	"os"
+	"strings"
 	"text/tabwriter"
 	"time"
 
-	"github.com/kaitai-io/kaitai_struct_go_runtime/kaitai"
-	"kaitai_parquet/kaitai_gen"
+	"kaitai_parquet/parquet"
 )
 
 func main() {
-	if len(os.Args) < 2 {
-		fmt.Fprintf(os.Stderr, "Usage: %s <parquet-file>\n", os.Args[0])
+	checksum := flag.String("checksum", "warn", "page CRC verification: strict, warn or off")
+	columnList := flag.String("columns", "", "comma-separated columns to read, by name or dotted path (default: all)")
+	flag.Usage = func() {
+		fmt.Fprintf(os.Stderr, "Usage: %s [-checksum strict|warn|off] [-columns a,b.c] <parquet-file>\n", os.Args[0])
+		fmt.Fprintf(os.Stderr, "       %s [-checksum strict|warn|off] bloom <parquet-file> <column> <value>\n", os.Args[0])
+	}
+	flag.Parse()
+	if flag.NArg() < 1 {
+		flag.Usage()
 		os.Exit(1)
 	}
 
-	filePath := os.Args[1]
+	var checksumMode parquet.ChecksumMode
+	switch *checksum {
+	case "strict":
+		checksumMode = parquet.ChecksumStrict
+	case "warn":
+		checksumMode = parquet.ChecksumWarn
+	case "off":
+		checksumMode = parquet.ChecksumOff
+	default:
+		fmt.Fprintf(os.Stderr, "Error: invalid -checksum value %q\n", *checksum)
+		os.Exit(1)
+	}
+
+	if flag.Arg(0) == "bloom" {
+		if flag.NArg() != 4 {
+			flag.Usage()
+			os.Exit(1)
+		}
+		if err := runBloom(flag.Arg(1), flag.Arg(2), flag.Arg(3), checksumMode); err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
+		}
+		return
+	}
 
 	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
 	defer cancel()
 
-	err := runParser(ctx, filePath)
+	var columns []string
+	for _, column := range strings.Split(*columnList, ",") {
+		if column = strings.TrimSpace(column); column != "" {
+			columns = append(columns, column)
+		}
+	}
+
+	err := runParser(ctx, flag.Arg(0), checksumMode, columns)
 	if err != nil {
 		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
 		os.Exit(1)
 	}
 }
 
-func runParser(ctx context.Context, filePath string) error {
+func runParser(ctx context.Context, filePath string, checksumMode parquet.ChecksumMode, columns []string) error {
 	// Open file
 	file, err := os.Open(filePath)
 	if err != nil {
@@ -37,6 +75,11 @@ func runParser(ctx context.Context, filePath string) error {
 	}
 	defer file.Close()
 
+	stat, err := file.Stat()
+	if err != nil {
+		return fmt.Errorf("error reading file info: %v", err)
+	}
+
 	// Check context
 	select {
 	case <-ctx.Done():
@@ -44,64 +87,95 @@ func runParser(ctx context.Context, filePath string) error {
 	default:
 	}
 
-	// Use Kaitai Struct parser to read file structure
-	// *os.File implements io.ReadSeeker which is required by kaitai.NewStream
-	stream := kaitai.NewStream(file)
-	parquet := kaitai_gen.NewParquet()
-	err = parquet.Read(stream, nil, parquet)
-	if err != nil {
-		return fmt.Errorf("error parsing parquet file: %v", err)
+	opts := []parquet.Option{
+		parquet.WithChecksumMode(checksumMode),
+		parquet.WithWarningHandler(func(err error) {
+			fmt.Fprintf(os.Stderr, "Warning: %v\n", err)
+		}),
 	}
-
-	// Verify magic numbers
-	if parquet.Magic != "PAR1" {
-		return fmt.Errorf("invalid magic at start: %s", parquet.Magic)
+	if len(columns) > 0 {
+		opts = append(opts, parquet.WithColumns(columns...))
 	}
-
-	// Read footer via Kaitai-generated Parquet parser (as Thrift Compact AST).
-	footerStruct, err := parquet.FooterThrift()
+	pf, err := parquet.OpenFile(file, stat.Size(), opts...)
 	if err != nil {
-		return fmt.Errorf("error reading footer: %v", err)
+		return err
 	}
+	metadata := pf.Metadata()
 
-	// Verify end magic
-	magicEnd, err := parquet.MagicEnd()
-	if err != nil {
-		return fmt.Errorf("error reading end magic: %v", err)
+	// Leaf columns, identified by their dotted path in the schema tree.
+	leaves := pf.Leaves()
+
+	// Print file-level metadata
+	fmt.Println("=== File Metadata ===")
+	fmt.Printf("Version: %d\n", metadata.Version)
+	if metadata.CreatedBy != nil {
+		fmt.Printf("Created by: %s\n", *metadata.CreatedBy)
 	}
-	if magicEnd != "PAR1" {
-		return fmt.Errorf("invalid magic at end: %s", magicEnd)
+	fmt.Printf("Rows: %d\n", metadata.NumRows)
+	if len(metadata.KeyValueMetadata) > 0 {
+		fmt.Println("Key/value metadata:")
+		for _, kv := range metadata.KeyValueMetadata {
+			if kv.Value == nil {
+				fmt.Printf("  %s\n", kv.Key)
+				continue
+			}
+			fmt.Printf("  %s = %s\n", kv.Key, truncate(*kv.Value, 80))
+		}
 	}
-
-	// Decode FileMetaData from the Thrift Compact AST.
-	_ = ctx // reserved for future cancellation checks in decoding
-	metadata, err := decodeFileMetaData(footerStruct)
-	if err != nil {
-		return fmt.Errorf("error extracting metadata: %v", err)
+	if len(metadata.ColumnOrders) > 0 {
+		typeDefined := 0
+		for _, order := range metadata.ColumnOrders {
+			if order.TypeDefinedOrder {
+				typeDefined++
+			}
+		}
+		fmt.Printf("Column orders: %d of %d type-defined\n", typeDefined, len(metadata.ColumnOrders))
 	}
-
-	// Extract column names from schema (skip root element)
-	columnNames := make([]string, 0)
-	for i, elem := range metadata.Schema {
-		if i == 0 {
-			continue // Skip root schema element
+	if alg := metadata.EncryptionAlgorithm; alg != nil {
+		switch {
+		case alg.AesGcmV1 != nil:
+			fmt.Println("Encryption: AES_GCM_V1 (plaintext footer)")
+		case alg.AesGcmCtrV1 != nil:
+			fmt.Println("Encryption: AES_GCM_CTR_V1 (plaintext footer)")
+		default:
+			fmt.Println("Encryption: unknown algorithm (plaintext footer)")
 		}
-		columnNames = append(columnNames, elem.Name)
 	}
+	if metadata.FooterSigningKeyMetadata != nil {
+		fmt.Printf("Footer signing key metadata: %d bytes\n", len(metadata.FooterSigningKeyMetadata))
+	}
+	fmt.Println()
 
 	// Print schema information
 	fmt.Println("=== Schema ===")
-	for i, name := range columnNames {
-		elem := metadata.Schema[i+1]
+	for i, leaf := range leaves {
+		elem := leaf.Element
 		repType := int32(0)
 		if elem.RepetitionType != nil {
 			repType = *elem.RepetitionType
 		}
-		typeName := getTypeName(elem.Type)
-		fmt.Printf("%d. %s (type: %d (%s), repetition: %d)\n", i+1, name, elem.Type, typeName, repType)
+		typeName := parquet.TypeName(elem.Type)
+		logical := ""
+		if lt := elem.ResolvedLogicalType(); lt != nil {
+			logical = ", logical: " + lt.String()
+		}
+		fmt.Printf("%d. %s (type: %d (%s), repetition: %d%s)\n", i+1, leaf.DottedPath(), elem.Type, typeName, repType, logical)
 	}
 	fmt.Println()
 
+	// Table columns are the top-level fields with a projected leaf; nested
+	// values are printed inline.
+	projected := make(map[string]bool)
+	for _, leaf := range pf.Projection() {
+		projected[leaf.Path[0]] = true
+	}
+	columnNames := make([]string, 0)
+	for _, field := range pf.Schema().Children {
+		if projected[field.Element.Name] {
+			columnNames = append(columnNames, field.Element.Name)
+		}
+	}
+
 	// Print column names
 	fmt.Println("=== Columns ===")
 	for i, name := range columnNames {
@@ -109,6 +183,45 @@ func runParser(ctx context.Context, filePath string) error {
 	}
 	fmt.Println()
 
+	// Print metadata and page headers of every projected column chunk
+	fmt.Println("=== Column Chunks ===")
+	for rgIdx, rowGroup := range pf.RowGroups() {
+		for _, leaf := range pf.Projection() {
+			colIdx := leaf.ColumnIndex
+			col, err := pf.ColumnChunk(rgIdx, colIdx)
+			if err != nil {
+				return err
+			}
+			fmt.Printf("Row group %d, column %s:\n", rgIdx, col.Leaf().DottedPath())
+
+			var order *parquet.ColumnOrder
+			if colIdx < len(metadata.ColumnOrders) {
+				order = &metadata.ColumnOrders[colIdx]
+			}
+			printColumnMetaData(col.Chunk().MetaData, col.Leaf().Element, order)
+			if err := printPageIndex(col, rowGroup.NumRows); err != nil {
+				fmt.Fprintf(os.Stderr, "Error reading page index of row group %d, column %s: %v\n", rgIdx, col.Leaf().DottedPath(), err)
+			}
+
+			pages, err := col.Pages()
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "Error reading pages of row group %d, column %s: %v\n", rgIdx, col.Leaf().DottedPath(), err)
+				continue
+			}
+			for i, page := range pages {
+				header := page.Header
+				crc := page.Checksum.String()
+				if header.CRC != nil {
+					crc = fmt.Sprintf("%08x %s", uint32(*header.CRC), page.Checksum)
+				}
+				fmt.Printf("  %d. %s at %d (values: %d, encoding: %d, compressed: %d, uncompressed: %d, crc: %s)\n",
+					i+1, parquet.PageTypeName(header.Type), page.Offset, header.NumValues(), header.Encoding(),
+					header.CompressedPageSize, header.UncompressedPageSize, crc)
+			}
+		}
+	}
+	fmt.Println()
+
 	// Read and print data
 	fmt.Println("=== Data ===")
 
@@ -131,66 +244,29 @@ func runParser(ctx context.Context, filePath string) error {
 	maxRows := 1000
 	rowsPrinted := 0
 
-	for _, rowGroup := range metadata.RowGroups {
+	for rgIdx := range pf.RowGroups() {
 		if rowsPrinted >= maxRows {
 			break
 		}
 
-		// Collect values from all columns
-		allColumnValues := make([][]interface{}, len(columnNames))
-
-		// Read data from each column chunk manually
-		for colIdx, colChunk := range rowGroup.Columns {
-			if colIdx >= len(columnNames) {
-				break
-			}
-
-			// Check if column has metadata
-			if colChunk.MetaData == nil {
-				allColumnValues[colIdx] = make([]interface{}, 0)
-				continue
-			}
-
-			// Read column data
-			var schemaElem SchemaElement
-			if colIdx+1 < len(metadata.Schema) {
-				schemaElem = metadata.Schema[colIdx+1]
-			}
-
-			values, err := readColumnValues(file, colChunk, schemaElem)
-			if err != nil {
-				fmt.Fprintf(os.Stderr, "Error reading column %s: %v\n", columnNames[colIdx], err)
-				allColumnValues[colIdx] = make([]interface{}, 0)
-				continue
-			}
-
-			allColumnValues[colIdx] = values
-		}
-
-		// Determine number of rows to print
-		maxRowsInData := 0
-		for _, colValues := range allColumnValues {
-			if len(colValues) > maxRowsInData {
-				maxRowsInData = len(colValues)
-			}
+		records, err := pf.Records(rgIdx)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error reading row group %d: %v\n", rgIdx, err)
+			continue
 		}
 
-		if maxRowsInData > maxRows-rowsPrinted {
-			maxRowsInData = maxRows - rowsPrinted
+		if len(records) > maxRows-rowsPrinted {
+			records = records[:maxRows-rowsPrinted]
 		}
 
 		// Print rows
-		for rowIdx := 0; rowIdx < maxRowsInData; rowIdx++ {
-			for colIdx := 0; colIdx < len(columnNames); colIdx++ {
-				if colIdx < len(allColumnValues) && rowIdx < len(allColumnValues[colIdx]) {
-					value := allColumnValues[colIdx][rowIdx]
-					if value == nil {
-						fmt.Fprintf(w, "NULL\t")
-					} else {
-						fmt.Fprintf(w, "%v\t", value)
-					}
-				} else {
+		for _, record := range records {
+			for _, name := range columnNames {
+				value := record[name]
+				if value == nil {
 					fmt.Fprintf(w, "NULL\t")
+				} else {
+					fmt.Fprintf(w, "%v\t", value)
 				}
 			}
 			fmt.Fprintf(w, "\n")
@@ -207,26 +283,134 @@ func runParser(ctx context.Context, filePath string) error {
 	return nil
 }
 
-// getTypeName returns a human-readable name for a Parquet type
-func getTypeName(typeID int32) string {
-	switch typeID {
-	case 0:
-		return "BOOLEAN"
-	case 1:
-		return "INT32"
-	case 2:
-		return "INT64"
-	case 3:
-		return "INT96"
-	case 4:
-		return "FLOAT"
-	case 5:
-		return "DOUBLE"
-	case 6:
-		return "BYTE_ARRAY"
-	case 7:
-		return "FIXED_LEN_BYTE_ARRAY"
-	default:
-		return "UNKNOWN"
+// truncate shortens s to at most n bytes for display, noting the full length.
+func truncate(s string, n int) string {
+	if len(s) <= n {
+		return s
+	}
+	return fmt.Sprintf("%s... (%d bytes)", s[:n], len(s))
+}
+
+// printColumnMetaData prints the footer metadata of a column chunk, with
+// min/max statistics decoded for the column's type.
+func printColumnMetaData(md *parquet.ColumnMetaData, elem parquet.SchemaElement, order *parquet.ColumnOrder) {
+	if md == nil {
+		fmt.Println("  (no metadata)")
+		return
+	}
+
+	fmt.Printf("  codec: %d, values: %d, encodings: %v, compressed: %d, uncompressed: %d\n",
+		md.Codec, md.NumValues, md.Encodings, md.TotalCompressedSize, md.TotalUncompressedSize)
+
+	if stats := md.Statistics; stats != nil {
+		line := "  statistics:"
+		min, max, ok, err := stats.Bounds(elem, order)
+		switch {
+		case err != nil:
+			line += fmt.Sprintf(" bounds unreadable (%v)", err)
+		case ok:
+			minValue, _ := parquet.ConvertValue(elem, min)
+			maxValue, _ := parquet.ConvertValue(elem, max)
+			line += fmt.Sprintf(" min %v, max %v", truncate(fmt.Sprint(minValue), 40), truncate(fmt.Sprint(maxValue), 40))
+		default:
+			line += " no usable bounds"
+		}
+		if stats.NullCount != nil {
+			line += fmt.Sprintf(", nulls %d", *stats.NullCount)
+		}
+		if stats.DistinctCount != nil {
+			line += fmt.Sprintf(", distinct %d", *stats.DistinctCount)
+		}
+		fmt.Println(line)
+	}
+
+	if len(md.EncodingStats) > 0 {
+		fmt.Print("  encoding stats:")
+		for _, es := range md.EncodingStats {
+			fmt.Printf(" %s/%d x%d", parquet.PageTypeName(es.PageType), es.Encoding, es.Count)
+		}
+		fmt.Println()
 	}
+
+	if ss := md.SizeStatistics; ss != nil {
+		line := "  size statistics:"
+		if ss.UnencodedByteArrayDataBytes != nil {
+			line += fmt.Sprintf(" unencoded bytes %d,", *ss.UnencodedByteArrayDataBytes)
+		}
+		line += fmt.Sprintf(" repetition levels %v, definition levels %v", ss.RepetitionLevelHistogram, ss.DefinitionLevelHistogram)
+		fmt.Println(line)
+	}
+
+	if gs := md.GeospatialStatistics; gs != nil {
+		line := fmt.Sprintf("  geospatial statistics: types %v", gs.GeospatialTypes)
+		if b := gs.BBox; b != nil {
+			line += fmt.Sprintf(", bbox x [%g, %g] y [%g, %g]", b.XMin, b.XMax, b.YMin, b.YMax)
+		}
+		fmt.Println(line)
+	}
+
+	if md.BloomFilterOffset != nil {
+		length := "unknown"
+		if md.BloomFilterLength != nil {
+			length = fmt.Sprint(*md.BloomFilterLength)
+		}
+		fmt.Printf("  bloom filter: offset %d, length %s\n", *md.BloomFilterOffset, length)
+	}
+	if md.IndexPageOffset != nil {
+		fmt.Printf("  index page offset: %d\n", *md.IndexPageOffset)
+	}
+	for _, kv := range md.KeyValueMeta {
+		if kv.Value == nil {
+			fmt.Printf("  %s\n", kv.Key)
+			continue
+		}
+		fmt.Printf("  %s = %s\n", kv.Key, truncate(*kv.Value, 80))
+	}
+}
+
+// printPageIndex prints the column and offset index of a column chunk, if it
+// has them.
+func printPageIndex(col *parquet.ColumnChunkReader, numRows int64) error {
+	columnIndex, err := col.ColumnIndex()
+	if err != nil {
+		return err
+	}
+	offsetIndex, err := col.OffsetIndex()
+	if err != nil {
+		return err
+	}
+
+	if columnIndex != nil {
+		elem := col.Leaf().Element
+		fmt.Printf("  column index: %d pages, boundary order %s\n", columnIndex.NumPages(), parquet.BoundaryOrderName(columnIndex.BoundaryOrder))
+		for i := 0; i < columnIndex.NumPages(); i++ {
+			line := fmt.Sprintf("    %d.", i+1)
+			min, max, ok, err := columnIndex.Bounds(i, elem)
+			switch {
+			case err != nil:
+				line += fmt.Sprintf(" bounds unreadable (%v)", err)
+			case columnIndex.NullPages[i]:
+				line += " null page"
+			case ok:
+				minValue, _ := parquet.ConvertValue(elem, min)
+				maxValue, _ := parquet.ConvertValue(elem, max)
+				line += fmt.Sprintf(" min %v, max %v", truncate(fmt.Sprint(minValue), 40), truncate(fmt.Sprint(maxValue), 40))
+			default:
+				line += " no usable bounds"
+			}
+			if i < len(columnIndex.NullCounts) {
+				line += fmt.Sprintf(", nulls %d", columnIndex.NullCounts[i])
+			}
+			fmt.Println(line)
+		}
+	}
+
+	if offsetIndex != nil {
+		fmt.Printf("  offset index: %d pages\n", len(offsetIndex.PageLocations))
+		for i, loc := range offsetIndex.PageLocations {
+			fmt.Printf("    %d. at %d (compressed: %d, first row: %d, rows: %d)\n",
+				i+1, loc.Offset, loc.CompressedPageSize, loc.FirstRowIndex, offsetIndex.NumRows(i, numRows))
+		}
+	}
+	return nil
 }
@@ -0,0 +1,326 @@
+package parquet
+
+import (
+	"bufio"
+	"encoding/binary"
+	"fmt"
+	"io"
+	"math"
+	"math/big"
+	"time"
+)
+
+// maxBloomFilterBytes bounds the bitset size accepted from a file, matching
+// the 128 MiB limit of the reference implementation.
+const maxBloomFilterBytes = 128 << 20
+
+// sbbfSalt holds the salts of the Split Block Bloom Filter, one per 32-bit
+// word of a block.
+var sbbfSalt = [8]uint32{
+	0x47b6137b, 0x44974d91, 0x8824ad5b, 0xa2b7289d,
+	0x705495c7, 0x2df1424b, 0x9efc4947, 0x5c6bfb31,
+}
+
+// BloomFilter is the Split Block Bloom Filter of a column chunk. Values are
+// hashed with XXH64 over their PLAIN encoding, without the length prefix of
+// BYTE_ARRAY values.
+type BloomFilter struct {
+	Header *BloomFilterHeader
+	elem   SchemaElement
+	bitset []byte
+}
+
+// BloomFilter reads the bloom filter of the chunk, or returns nil if the chunk
+// has none.
+func (c *ColumnChunkReader) BloomFilter() (*BloomFilter, error) {
+	md := c.chunk.MetaData
+	if md == nil || md.BloomFilterOffset == nil {
+		return nil, nil
+	}
+
+	// Older writers leave out the length, so the header is parsed from the
+	// stream and the bitset size taken from it.
+	offset := *md.BloomFilterOffset
+	size := int64(math.MaxInt64 - offset)
+	if md.BloomFilterLength != nil {
+		size = int64(*md.BloomFilterLength)
+	}
+	rbuf := bufio.NewReader(io.NewSectionReader(c.r, offset, size))
+
+	st, _, err := parseCompactStructFromBufio(rbuf, 4096)
+	if err != nil {
+		return nil, fmt.Errorf("parsing bloom filter header at offset %d: %v", offset, err)
+	}
+	header, err := decodeBloomFilterHeader(st)
+	if err != nil {
+		return nil, fmt.Errorf("decoding bloom filter header at offset %d: %v", offset, err)
+	}
+
+	switch {
+	case !header.Algorithm.Block:
+		return nil, fmt.Errorf("unsupported bloom filter algorithm")
+	case !header.Hash.XxHash:
+		return nil, fmt.Errorf("unsupported bloom filter hash")
+	case !header.Compression.Uncompressed:
+		return nil, fmt.Errorf("unsupported bloom filter compression")
+	case header.NumBytes <= 0 || header.NumBytes%32 != 0 || header.NumBytes > maxBloomFilterBytes:
+		return nil, fmt.Errorf("invalid bloom filter size: %d bytes", header.NumBytes)
+	}
+
+	bitset := make([]byte, header.NumBytes)
+	if _, err := io.ReadFull(rbuf, bitset); err != nil {
+		return nil, fmt.Errorf("reading bloom filter bitset of %d bytes: %v", header.NumBytes, err)
+	}
+	return &BloomFilter{Header: header, elem: c.leaf.Element, bitset: bitset}, nil
+}
+
+// MightContain reports whether value may be in the column chunk. false means
+// it definitely is not. value is given like the logical values of Records;
+// numbers of any Go type are accepted if they convert exactly, and TIME values
+// may be a TimeOfDay, a time.Duration or nanoseconds. An error is returned for
+// values that cannot be represented in the column and for BYTE_ARRAY decimals,
+// whose encoded form is not unique.
+func (b *BloomFilter) MightContain(value interface{}) (bool, error) {
+	plain, err := plainValue(b.elem, value)
+	if err != nil {
+		return false, err
+	}
+	if b.containsHash(xxHash64(plain)) {
+		return true, nil
+	}
+
+	// 0.0 and -0.0 are equal but hash differently, so check the other one.
+	switch {
+	case b.elem.Type == 4 && binary.LittleEndian.Uint32(plain)&^(1<<31) == 0: // FLOAT
+		plain = binary.LittleEndian.AppendUint32(nil, binary.LittleEndian.Uint32(plain)^1<<31)
+	case b.elem.Type == 5 && binary.LittleEndian.Uint64(plain)&^(1<<63) == 0: // DOUBLE
+		plain = binary.LittleEndian.AppendUint64(nil, binary.LittleEndian.Uint64(plain)^1<<63)
+	default:
+		return false, nil
+	}
+	return b.containsHash(xxHash64(plain)), nil
+}
+
+// containsHash checks the block selected by the upper 32 bits of the hash for
+// the eight bits derived from its lower 32 bits.
+func (b *BloomFilter) containsHash(hash uint64) bool {
+	numBlocks := uint64(len(b.bitset) / 32)
+	block := b.bitset[(((hash>>32)*numBlocks)>>32)*32:]
+	key := uint32(hash)
+	for i, salt := range sbbfSalt {
+		word := binary.LittleEndian.Uint32(block[i*4:])
+		if word&(1<<((key*salt)>>27)) == 0 {
+			return false
+		}
+	}
+	return true
+}
+
+// plainValue returns the PLAIN encoding of value in a column with the given
+// schema element, BYTE_ARRAY values without their length prefix. It reverses
+// ConvertValue.
+func plainValue(elem SchemaElement, value interface{}) ([]byte, error) {
+	lt := elem.ResolvedLogicalType()
+	if lt != nil {
+		switch lt.Kind {
+		case LogicalTypeDecimal:
+			if lt.Decimal != nil {
+				return plainDecimal(elem, lt.Decimal.Scale, value)
+			}
+		case LogicalTypeDate:
+			switch v := value.(type) {
+			case Date:
+				value = v.Time().Unix() / 86400
+			case time.Time:
+				value = time.Date(v.Year(), v.Month(), v.Day(), 0, 0, 0, 0, time.UTC).Unix() / 86400
+			}
+		case LogicalTypeTime:
+			// Like TimeOfDay, plain numbers are nanoseconds since midnight.
+			if r, ok := toRat(value); ok && r.IsInt() && r.Num().IsInt64() && lt.Time != nil {
+				d := time.Duration(r.Num().Int64())
+				unit := timeUnitDuration(lt.Time.Unit)
+				if d%unit != 0 {
+					return nil, fmt.Errorf("%v is finer than the column's %s unit", value, lt.Time.Unit)
+				}
+				value = int64(d / unit)
+			}
+		case LogicalTypeTimestamp:
+			if t, ok := value.(time.Time); ok && lt.Timestamp != nil {
+				switch lt.Timestamp.Unit {
+				case TimeUnitMillis:
+					value = t.UnixMilli()
+				case TimeUnitMicros:
+					value = t.UnixMicro()
+				case TimeUnitNanos:
+					value = t.UnixNano()
+				}
+			}
+		case LogicalTypeUUID:
+			if u, ok := value.(UUID); ok {
+				value = string(u[:])
+			}
+		}
+	}
+
+	switch elem.Type {
+	case 1, 2: // INT32, INT64
+		r, ok := toRat(value)
+		if !ok || !r.IsInt() {
+			return nil, fmt.Errorf("cannot use %v (%T) as an integer", value, value)
+		}
+		n := r.Num()
+		unsigned := lt != nil && lt.Kind == LogicalTypeInteger && lt.Integer != nil && !lt.Integer.IsSigned
+		if elem.Type == 1 {
+			if !fitsInt(n, 32, unsigned) {
+				return nil, fmt.Errorf("%v is out of range for the column", value)
+			}
+			return binary.LittleEndian.AppendUint32(nil, uint32(intBits(n))), nil
+		}
+		if !fitsInt(n, 64, unsigned) {
+			return nil, fmt.Errorf("%v is out of range for the column", value)
+		}
+		return binary.LittleEndian.AppendUint64(nil, intBits(n)), nil
+	case 4: // FLOAT
+		if f, ok := value.(float32); ok {
+			return binary.LittleEndian.AppendUint32(nil, math.Float32bits(f)), nil
+		}
+		if f, ok := value.(float64); ok && float64(float32(f)) == f {
+			return binary.LittleEndian.AppendUint32(nil, math.Float32bits(float32(f))), nil
+		}
+		r, ok := toRat(value)
+		if !ok {
+			return nil, fmt.Errorf("cannot use %v (%T) as a float", value, value)
+		}
+		f, exact := r.Float32()
+		if !exact {
+			return nil, fmt.Errorf("%v is not representable as a float", value)
+		}
+		return binary.LittleEndian.AppendUint32(nil, math.Float32bits(f)), nil
+	case 5: // DOUBLE
+		switch f := value.(type) {
+		case float64:
+			return binary.LittleEndian.AppendUint64(nil, math.Float64bits(f)), nil
+		case float32:
+			return binary.LittleEndian.AppendUint64(nil, math.Float64bits(float64(f))), nil
+		}
+		r, ok := toRat(value)
+		if !ok {
+			return nil, fmt.Errorf("cannot use %v (%T) as a double", value, value)
+		}
+		f, exact := r.Float64()
+		if !exact {
+			return nil, fmt.Errorf("%v is not representable as a double", value)
+		}
+		return binary.LittleEndian.AppendUint64(nil, math.Float64bits(f)), nil
+	case 3: // INT96
+		switch v := value.(type) {
+		case Int96:
+			return v[:], nil
+		case time.Time:
+			days := v.Unix() / 86400
+			if v.Unix()%86400 < 0 {
+				days--
+			}
+			nanos := (v.Unix()-days*86400)*int64(time.Second) + int64(v.Nanosecond())
+			out := binary.LittleEndian.AppendUint64(nil, uint64(nanos))
+			return binary.LittleEndian.AppendUint32(out, uint32(days+julianDayOfUnixEpoch)), nil
+		}
+	case 6, 7: // BYTE_ARRAY, FIXED_LEN_BYTE_ARRAY
+		var raw []byte
+		switch v := value.(type) {
+		case string:
+			raw = []byte(v)
+		case []byte:
+			raw = v
+		default:
+			return nil, fmt.Errorf("cannot use %v (%T) as a byte array", value, value)
+		}
+		if elem.Type == 7 && (elem.TypeLength == nil || len(raw) != int(*elem.TypeLength)) {
+			return nil, fmt.Errorf("value has %d bytes, not the column's type_length", len(raw))
+		}
+		return raw, nil
+	}
+	return nil, fmt.Errorf("cannot use %v (%T) in a %s column", value, value, TypeName(elem.Type))
+}
+
+// plainDecimal encodes a decimal value, given as a Decimal or any number, with
+// the column's scale and physical type.
+func plainDecimal(elem SchemaElement, scale int32, value interface{}) ([]byte, error) {
+	r, ok := toRat(value)
+	if !ok {
+		return nil, fmt.Errorf("cannot use %v (%T) as a decimal", value, value)
+	}
+	r = new(big.Rat).Mul(r, new(big.Rat).SetInt(new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(scale)), nil)))
+	if !r.IsInt() {
+		return nil, fmt.Errorf("%v has more than %d decimal places", value, scale)
+	}
+	unscaled := r.Num()
+
+	switch elem.Type {
+	case 1: // INT32
+		if !fitsInt(unscaled, 32, false) {
+			return nil, fmt.Errorf("%v is out of range for the column", value)
+		}
+		return binary.LittleEndian.AppendUint32(nil, uint32(unscaled.Int64())), nil
+	case 2: // INT64
+		if !fitsInt(unscaled, 64, false) {
+			return nil, fmt.Errorf("%v is out of range for the column", value)
+		}
+		return binary.LittleEndian.AppendUint64(nil, uint64(unscaled.Int64())), nil
+	case 6: // BYTE_ARRAY
+		// Writers are free to sign-extend the value to any width, and each
+		// width hashes differently.
+		return nil, fmt.Errorf("BYTE_ARRAY decimals have no canonical encoding")
+	case 7: // FIXED_LEN_BYTE_ARRAY
+		if elem.TypeLength == nil {
+			return nil, fmt.Errorf("FIXED_LEN_BYTE_ARRAY column without type_length")
+		}
+		n := int(*elem.TypeLength)
+		if !fitsInt(unscaled, 8*n, false) {
+			return nil, fmt.Errorf("%v is out of range for the column", value)
+		}
+		return twosComplement(unscaled, n), nil
+	}
+	return nil, fmt.Errorf("DECIMAL column with physical type %s", TypeName(elem.Type))
+}
+
+// twosComplement returns n as a big-endian two's complement number of size
+// bytes. n must fit.
+func twosComplement(n *big.Int, size int) []byte {
+	v := n
+	if n.Sign() < 0 {
+		v = new(big.Int).Add(new(big.Int).Lsh(big.NewInt(1), uint(8*size)), n)
+	}
+	return v.FillBytes(make([]byte, size))
+}
+
+// fitsInt reports whether n fits in a signed or unsigned integer of the given
+// number of bits.
+func fitsInt(n *big.Int, bits int, unsigned bool) bool {
+	if unsigned {
+		return n.Sign() >= 0 && n.BitLen() <= bits
+	}
+	if n.Sign() >= 0 {
+		return n.BitLen() < bits
+	}
+	return new(big.Int).Not(n).BitLen() < bits
+}
+
+// intBits returns the low 64 bits of n in two's complement.
+func intBits(n *big.Int) uint64 {
+	if n.Sign() >= 0 {
+		return n.Uint64()
+	}
+	return uint64(n.Int64())
+}
+
+func timeUnitDuration(unit TimeUnit) time.Duration {
+	switch unit {
+	case TimeUnitMillis:
+		return time.Millisecond
+	case TimeUnitMicros:
+		return time.Microsecond
+	default:
+		return time.Nanosecond
+	}
+}
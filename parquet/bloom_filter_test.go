@@ -0,0 +1,192 @@
+package parquet
+
+import (
+	"bytes"
+	"encoding/binary"
+	"math"
+	"math/big"
+	"testing"
+	"time"
+)
+
+func TestPlainValue(t *testing.T) {
+	decimal := func(physical int32, scale int32) SchemaElement {
+		return SchemaElement{Type: physical, LogicalType: &LogicalType{Kind: LogicalTypeDecimal, Decimal: &DecimalType{Scale: scale, Precision: 9}}}
+	}
+	timeMillis := SchemaElement{Type: 1, LogicalType: &LogicalType{Kind: LogicalTypeTime, Time: &TimeType{Unit: TimeUnitMillis}}}
+	flba2 := decimal(7, 0)
+	flba2.TypeLength = ptr(int32(2))
+
+	tests := []struct {
+		name  string
+		elem  SchemaElement
+		value interface{}
+		want  []byte // nil for an error
+	}{
+		{"int32", SchemaElement{Type: 1}, 7, []byte{7, 0, 0, 0}},
+		{"int32 negative", SchemaElement{Type: 1}, int64(-1), []byte{0xff, 0xff, 0xff, 0xff}},
+		{"int32 overflow", SchemaElement{Type: 1}, int64(1) << 31, nil},
+		{"uint32", SchemaElement{Type: 1, ConvertedType: ptr(int32(13))}, uint32(math.MaxUint32), []byte{0xff, 0xff, 0xff, 0xff}},
+		{"uint32 negative", SchemaElement{Type: 1, ConvertedType: ptr(int32(13))}, -1, nil},
+		{"int64 from float", SchemaElement{Type: 2}, 2.0, []byte{2, 0, 0, 0, 0, 0, 0, 0}},
+		{"int64 fraction", SchemaElement{Type: 2}, 2.5, nil},
+		{"float", SchemaElement{Type: 4}, float32(1), []byte{0, 0, 0x80, 0x3f}},
+		{"float inexact double", SchemaElement{Type: 4}, 0.1, nil},
+		{"double from float", SchemaElement{Type: 5}, float32(1.5), []byte{0, 0, 0, 0, 0, 0, 0xf8, 0x3f}},
+		{"double from int", SchemaElement{Type: 5}, 3, []byte{0, 0, 0, 0, 0, 0, 0x08, 0x40}},
+		{"boolean", SchemaElement{Type: 0}, true, nil},
+
+		// BYTE_ARRAY decimals may be sign-extended to any width by writers.
+		{"decimal byte array", decimal(6, 0), 150, nil},
+		{"decimal byte array negative", decimal(6, 0), -129, nil},
+		{"decimal byte array zero", decimal(6, 2), Decimal{Unscaled: big.NewInt(0), Scale: 2}, nil},
+		{"decimal scaled", decimal(1, 2), Decimal{Unscaled: big.NewInt(129), Scale: 2}, []byte{0x81, 0, 0, 0}},
+		{"decimal rescaled", decimal(2, 2), Decimal{Unscaled: big.NewInt(13), Scale: 1}, []byte{0x82, 0, 0, 0, 0, 0, 0, 0}},
+		{"decimal too precise", decimal(1, 2), 1.295, nil},
+		{"decimal fixed", flba2, -1, []byte{0xff, 0xff}},
+		{"decimal fixed overflow", flba2, 40000, nil},
+
+		{"date", SchemaElement{Type: 1, ConvertedType: ptr(int32(6))}, Date{Year: 1970, Month: 1, Day: 2}, []byte{1, 0, 0, 0}},
+		{"date from time", SchemaElement{Type: 1, ConvertedType: ptr(int32(6))}, time.Date(2000, 1, 1, 15, 4, 5, 0, time.UTC), []byte{0xcd, 0x2a, 0, 0}},
+		{"time", timeMillis, TimeOfDay(1500 * time.Millisecond), []byte{0xdc, 0x05, 0, 0}},
+		{"time from duration", timeMillis, 1500 * time.Millisecond, []byte{0xdc, 0x05, 0, 0}},
+		{"time finer than unit", timeMillis, TimeOfDay(1500*time.Millisecond + time.Microsecond), nil},
+		{"timestamp", SchemaElement{Type: 2, ConvertedType: ptr(int32(10))}, time.Unix(1, 0), []byte{0x40, 0x42, 0x0f, 0, 0, 0, 0, 0}},
+		{"int96", SchemaElement{Type: 3}, time.Unix(1, 0), []byte{0x00, 0xca, 0x9a, 0x3b, 0, 0, 0, 0, 0x8c, 0x3d, 0x25, 0x00}},
+
+		{"string", SchemaElement{Type: 6}, "hi", []byte("hi")},
+		{"bytes", SchemaElement{Type: 6}, []byte{1, 2}, []byte{1, 2}},
+		{"not a byte array", SchemaElement{Type: 6}, 1, nil},
+		{"fixed length mismatch", SchemaElement{Type: 7, TypeLength: ptr(int32(3))}, "hi", nil},
+		{"uuid", SchemaElement{Type: 7, TypeLength: ptr(int32(16)), LogicalType: &LogicalType{Kind: LogicalTypeUUID}}, UUID{15: 1}, append(make([]byte, 15), 1)},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := plainValue(tt.elem, tt.value)
+			if tt.want == nil {
+				if err == nil {
+					t.Fatalf("got %x, expected an error", got)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("plainValue: %v", err)
+			}
+			if !bytes.Equal(got, tt.want) {
+				t.Errorf("got %x, want %x", got, tt.want)
+			}
+		})
+	}
+}
+
+// newTestBloomFilter builds a filter of numBytes holding the given values,
+// inserting them as described in the Parquet bloom filter spec.
+func newTestBloomFilter(t *testing.T, elem SchemaElement, numBytes int, values ...interface{}) *BloomFilter {
+	t.Helper()
+	b := &BloomFilter{elem: elem, bitset: make([]byte, numBytes)}
+	for _, v := range values {
+		plain, err := plainValue(elem, v)
+		if err != nil {
+			t.Fatalf("plainValue(%v): %v", v, err)
+		}
+		hash := xxHash64(plain)
+		block := b.bitset[(((hash>>32)*uint64(numBytes/32))>>32)*32:]
+		for i, salt := range sbbfSalt {
+			word := binary.LittleEndian.Uint32(block[i*4:])
+			word |= 1 << ((uint32(hash) * salt) >> 27)
+			binary.LittleEndian.PutUint32(block[i*4:], word)
+		}
+	}
+	return b
+}
+
+func TestBloomFilterMightContain(t *testing.T) {
+	var even, odd []interface{}
+	for i := int64(0); i < 200; i += 2 {
+		even = append(even, i)
+		odd = append(odd, i+1)
+	}
+	b := newTestBloomFilter(t, SchemaElement{Type: 2}, 1024, even...)
+
+	for _, v := range even {
+		if found, err := b.MightContain(v); err != nil || !found {
+			t.Errorf("MightContain(%v) = %v, %v; want true", v, found, err)
+		}
+	}
+	// With 32 blocks for 100 values false positives are rare.
+	falsePositives := 0
+	for _, v := range odd {
+		found, err := b.MightContain(v)
+		if err != nil {
+			t.Fatalf("MightContain(%v): %v", v, err)
+		}
+		if found {
+			falsePositives++
+		}
+	}
+	if falsePositives > 5 {
+		t.Errorf("%d of %d absent values found", falsePositives, len(odd))
+	}
+
+	// Other Go types that convert exactly hash like the stored value.
+	if found, err := b.MightContain(uint8(42)); err != nil || !found {
+		t.Errorf("MightContain(uint8(42)) = %v, %v; want true", found, err)
+	}
+	if _, err := b.MightContain("42"); err == nil {
+		t.Error("MightContain(\"42\"): expected an error")
+	}
+
+	// Decimals stored as BYTE_ARRAY are never ruled out.
+	decimals := &BloomFilter{
+		elem:   SchemaElement{Type: 6, LogicalType: &LogicalType{Kind: LogicalTypeDecimal, Decimal: &DecimalType{Scale: 0, Precision: 9}}},
+		bitset: make([]byte, 32),
+	}
+	if _, err := decimals.MightContain(150); err == nil {
+		t.Error("BYTE_ARRAY decimal: expected an error")
+	}
+}
+
+func TestBloomFilterStrings(t *testing.T) {
+	b := newTestBloomFilter(t, SchemaElement{Type: 6}, 256, "apple", "banana", "")
+	tests := []struct {
+		value interface{}
+		want  bool
+	}{
+		{"apple", true},
+		{[]byte("banana"), true},
+		{"", true},
+		{"cherry", false},
+		{"Apple", false},
+	}
+	for _, tt := range tests {
+		found, err := b.MightContain(tt.value)
+		if err != nil {
+			t.Fatalf("MightContain(%q): %v", tt.value, err)
+		}
+		if found != tt.want {
+			t.Errorf("MightContain(%q) = %v, want %v", tt.value, found, tt.want)
+		}
+	}
+}
+
+func TestBloomFilterSignedZero(t *testing.T) {
+	tests := []struct {
+		name   string
+		elem   SchemaElement
+		stored interface{}
+		value  interface{}
+	}{
+		{"double -0 finds 0", SchemaElement{Type: 5}, math.Copysign(0, -1), 0.0},
+		{"double 0 finds -0", SchemaElement{Type: 5}, 0.0, math.Copysign(0, -1)},
+		{"float -0 finds 0", SchemaElement{Type: 4}, float32(math.Copysign(0, -1)), float32(0)},
+		{"float 0 finds -0", SchemaElement{Type: 4}, float32(0), float32(math.Copysign(0, -1))},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			b := newTestBloomFilter(t, tt.elem, 32, tt.stored)
+			if found, err := b.MightContain(tt.value); err != nil || !found {
+				t.Errorf("MightContain(%v) = %v, %v; want true", tt.value, found, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,44 @@
+package parquet
+
+import (
+	"fmt"
+)
+
+// decodeByteStreamSplit decodes BYTE_STREAM_SPLIT data. The encoder scatters
+// byte k of every value into stream k; the streams are stored back to back, so
+// byte k of value i lives at data[k*n+i] where n is the number of values.
+// Supported for FLOAT, DOUBLE, INT32, INT64 and FIXED_LEN_BYTE_ARRAY.
+func decodeByteStreamSplit(data []byte, dataType int32, typeLength int, numValues int) ([]interface{}, error) {
+	var width int
+	switch dataType {
+	case 1, 4: // INT32, FLOAT
+		width = 4
+	case 2, 5: // INT64, DOUBLE
+		width = 8
+	case 7: // FIXED_LEN_BYTE_ARRAY
+		width = typeLength
+	default:
+		return nil, fmt.Errorf("BYTE_STREAM_SPLIT is not valid for data type %d", dataType)
+	}
+	if width <= 0 {
+		return nil, fmt.Errorf("byte stream split: invalid type length %d", width)
+	}
+	if len(data)%width != 0 {
+		return nil, fmt.Errorf("byte stream split: data length %d is not a multiple of %d", len(data), width)
+	}
+
+	n := len(data) / width
+	if n < numValues {
+		return nil, fmt.Errorf("byte stream split: got %d values, expected %d", n, numValues)
+	}
+
+	// Reassemble the values into PLAIN layout.
+	plain := make([]byte, len(data))
+	for i := 0; i < n; i++ {
+		for k := 0; k < width; k++ {
+			plain[i*width+k] = data[k*n+i]
+		}
+	}
+
+	return decodePlainValues(plain, dataType, width, numValues)
+}
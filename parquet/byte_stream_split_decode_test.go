@@ -0,0 +1,58 @@
+package parquet
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestDecodeByteStreamSplit(t *testing.T) {
+	tests := []struct {
+		name       string
+		data       []byte
+		dataType   int32
+		typeLength int
+		numValues  int
+		want       []interface{}
+	}{
+		{"float", []byte{0x00, 0x00, 0x00, 0x00, 0x80, 0x20, 0x3f, 0x40}, 4, 0, 2, []interface{}{float32(1), float32(2.5)}},
+		{"int32", []byte{0x01, 0x00, 0x00, 0x01, 0x00, 0x00, 0x00, 0x00}, 1, 0, 2, []interface{}{int32(1), int32(256)}},
+		{"double", []byte{0, 0, 0, 0, 0, 0, 0xf0, 0x3f}, 5, 0, 1, []interface{}{float64(1)}},
+		{"int64", []byte{0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff}, 2, 0, 1, []interface{}{int64(-1)}},
+		{"fixed len byte array", []byte("axbycz"), 7, 3, 2, []interface{}{"abc", "xyz"}},
+		{"empty", nil, 4, 0, 0, []interface{}{}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := decodeByteStreamSplit(tt.data, tt.dataType, tt.typeLength, tt.numValues)
+			if err != nil {
+				t.Fatalf("decodeByteStreamSplit: %v", err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("got %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestDecodeByteStreamSplitErrors(t *testing.T) {
+	tests := []struct {
+		name       string
+		data       []byte
+		dataType   int32
+		typeLength int
+		numValues  int
+	}{
+		{"boolean", []byte{1}, 0, 0, 1},
+		{"byte array", []byte("abcd"), 6, 0, 1},
+		{"missing type length", []byte("abcd"), 7, 0, 1},
+		{"ragged streams", []byte{1, 2, 3, 4, 5}, 1, 0, 1},
+		{"too few values", []byte{1, 2, 3, 4}, 1, 0, 2},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := decodeByteStreamSplit(tt.data, tt.dataType, tt.typeLength, tt.numValues); err == nil {
+				t.Error("expected an error")
+			}
+		})
+	}
+}
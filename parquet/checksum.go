@@ -0,0 +1,70 @@
+package parquet
+
+import (
+	"fmt"
+	"hash/crc32"
+)
+
+// ChecksumMode controls verification of the optional page CRC (PageHeader
+// field 4).
+type ChecksumMode int
+
+const (
+	// ChecksumOff skips verification.
+	ChecksumOff ChecksumMode = iota
+	// ChecksumWarn reports mismatches to the warning handler and keeps reading.
+	ChecksumWarn
+	// ChecksumStrict fails the read on a mismatch.
+	ChecksumStrict
+)
+
+// ChecksumStatus is the outcome of checking a single page's CRC.
+type ChecksumStatus int
+
+const (
+	// ChecksumAbsent means the page header carries no CRC.
+	ChecksumAbsent ChecksumStatus = iota
+	// ChecksumValid means the CRC matches the page bytes.
+	ChecksumValid
+	// ChecksumMismatch means the CRC does not match the page bytes.
+	ChecksumMismatch
+)
+
+func (s ChecksumStatus) String() string {
+	switch s {
+	case ChecksumAbsent:
+		return "absent"
+	case ChecksumValid:
+		return "ok"
+	case ChecksumMismatch:
+		return "MISMATCH"
+	default:
+		return "unknown"
+	}
+}
+
+// ChecksumError reports a page whose CRC does not match its contents.
+type ChecksumError struct {
+	Offset   int64 // file offset of the page header
+	Expected uint32
+	Actual   uint32
+}
+
+func (e *ChecksumError) Error() string {
+	return fmt.Sprintf("page at offset %d: crc mismatch: header has %08x, page data has %08x", e.Offset, e.Expected, e.Actual)
+}
+
+// verifyPageChecksum checks the CRC-32 (IEEE, as used by gzip) of a page body
+// as stored in the file, that is after compression, against its header. The
+// returned error is non-nil only for ChecksumMismatch.
+func verifyPageChecksum(header *PageHeader, body []byte, offset int64) (ChecksumStatus, error) {
+	if header.CRC == nil {
+		return ChecksumAbsent, nil
+	}
+	expected := uint32(*header.CRC)
+	actual := crc32.ChecksumIEEE(body)
+	if actual != expected {
+		return ChecksumMismatch, &ChecksumError{Offset: offset, Expected: expected, Actual: actual}
+	}
+	return ChecksumValid, nil
+}
@@ -0,0 +1,126 @@
+package parquet
+
+import (
+	"bytes"
+	"errors"
+	"hash/crc32"
+	"reflect"
+	"testing"
+
+	"github.com/klauspost/compress/snappy"
+)
+
+func TestVerifyPageChecksum(t *testing.T) {
+	crc := func(v uint32) *int32 {
+		c := int32(v)
+		return &c
+	}
+	// A PLAIN INT32 page body holding 1, 2, 3.
+	page := []byte{1, 0, 0, 0, 2, 0, 0, 0, 3, 0, 0, 0}
+
+	tests := []struct {
+		name   string
+		crc    *int32
+		body   []byte
+		status ChecksumStatus
+	}{
+		{"absent", nil, page, ChecksumAbsent},
+		{"check value", crc(0xcbf43926), []byte("123456789"), ChecksumValid},
+		{"page", crc(0xb0e02293), page, ChecksumValid},
+		{"empty page", crc(0), nil, ChecksumValid},
+		{"corrupted page", crc(0xb0e02293), []byte{1, 0, 0, 0, 2, 0, 0, 0, 4, 0, 0, 0}, ChecksumMismatch},
+		{"crc off by one bit", crc(0x30e02293), page, ChecksumMismatch},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			status, err := verifyPageChecksum(&PageHeader{CRC: tt.crc}, tt.body, 42)
+			if status != tt.status {
+				t.Errorf("got status %v, want %v", status, tt.status)
+			}
+			var ce *ChecksumError
+			if tt.status != ChecksumMismatch {
+				if err != nil {
+					t.Errorf("unexpected error: %v", err)
+				}
+				return
+			}
+			if !errors.As(err, &ce) {
+				t.Fatalf("got %v, want a *ChecksumError", err)
+			}
+			if ce.Offset != 42 || ce.Expected != uint32(*tt.crc) {
+				t.Errorf("got %+v, want offset 42 and expected %08x", ce, uint32(*tt.crc))
+			}
+		})
+	}
+}
+
+func TestReadColumnChunkChecksumV2(t *testing.T) {
+	// One DATA_PAGE_V2 of an optional INT32 column: the definition levels
+	// 1 0 1 are stored as is, the values 1 and 2 snappy-compressed.
+	levels := []byte{0x03, 0x05}
+	values := []byte{1, 0, 0, 0, 2, 0, 0, 0}
+	body := append(append([]byte{}, levels...), snappy.Encode(nil, values)...)
+	chunkWithCRC := func(crc uint32) []byte {
+		var w compactWriter
+		w.begin()
+		w.i32(1, 3) // type: DATA_PAGE_V2
+		w.i32(2, int32(len(levels)+len(values)))
+		w.i32(3, int32(len(body)))
+		w.i32(4, int32(crc))
+		w.structField(8, func() {
+			w.i32(1, 3) // num_values
+			w.i32(2, 1) // num_nulls
+			w.i32(3, 3) // num_rows
+			w.i32(4, 0) // encoding: PLAIN
+			w.i32(5, int32(len(levels)))
+			w.i32(6, 0)
+		})
+		w.end()
+		w.Write(body)
+		return w.Bytes()
+	}
+
+	numChildren := int32(1)
+	_, leaves, err := buildSchemaTree([]SchemaElement{{Name: "schema", NumChildren: &numChildren}, testLeaf("v", 1, 1)})
+	if err != nil {
+		t.Fatalf("buildSchemaTree: %v", err)
+	}
+	read := func(data []byte, opts options) (*columnData, error) {
+		chunk := ColumnChunk{MetaData: &ColumnMetaData{
+			Type: 1, Codec: 1, NumValues: 3, TotalCompressedSize: int64(len(data)),
+		}}
+		return readColumnChunk(bytes.NewReader(data), chunk, leaves[0], opts)
+	}
+	strict := defaultOptions()
+	strict.checksum = ChecksumStrict
+
+	// The CRC covers the page as stored: the uncompressed levels followed by
+	// the compressed values.
+	data, err := read(chunkWithCRC(crc32.ChecksumIEEE(body)), strict)
+	if err != nil {
+		t.Fatalf("CRC of the stored page: %v", err)
+	}
+	if want := []interface{}{int32(1), int32(2)}; !reflect.DeepEqual(data.values, want) {
+		t.Errorf("got %v, want %v", data.values, want)
+	}
+
+	// A CRC over the fully uncompressed page does not match.
+	uncompressed := append(append([]byte{}, levels...), values...)
+	badChunk := chunkWithCRC(crc32.ChecksumIEEE(uncompressed))
+	var ce *ChecksumError
+	if _, err := read(badChunk, strict); !errors.As(err, &ce) {
+		t.Errorf("CRC of the uncompressed page: got %v, want a *ChecksumError", err)
+	}
+
+	// In warn mode the mismatch is reported and the page still read.
+	var warnings []error
+	warn := defaultOptions()
+	warn.checksum = ChecksumWarn
+	warn.warn = func(err error) { warnings = append(warnings, err) }
+	if _, err := read(badChunk, warn); err != nil {
+		t.Fatalf("warn mode: %v", err)
+	}
+	if len(warnings) != 1 {
+		t.Errorf("warn mode: got warnings %v, want one", warnings)
+	}
+}
@@ -0,0 +1,433 @@
+package parquet
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+)
+
+// ColumnChunkReader decodes the values of a single column chunk.
+type ColumnChunkReader struct {
+	r       io.ReaderAt
+	chunk   ColumnChunk
+	leaf    *SchemaNode
+	opts    options
+	numRows int64
+	// cursor is the read position of ReadRows, nil until first used.
+	cursor      *rowCursor
+	offsetIndex *OffsetIndex
+}
+
+// Chunk returns the column chunk metadata from the footer.
+func (c *ColumnChunkReader) Chunk() ColumnChunk {
+	return c.chunk
+}
+
+// Schema returns the schema element of the column.
+func (c *ColumnChunkReader) Schema() SchemaElement {
+	return c.leaf.Element
+}
+
+// Leaf returns the schema tree node of the column.
+func (c *ColumnChunkReader) Leaf() *SchemaNode {
+	return c.leaf
+}
+
+// Values decodes every value in the chunk, one entry per level slot. NULLs
+// (and, for repeated columns, empty or missing lists) are returned as nil
+// entries so that positions line up across flat columns. The dynamic type of
+// each non-nil value depends on the column's physical type (see the typed
+// readers below). Use File.Records to reassemble nested data.
+func (c *ColumnChunkReader) Values() ([]interface{}, error) {
+	data, err := readColumnChunk(c.r, c.chunk, c.leaf, c.opts)
+	if err != nil {
+		return nil, fmt.Errorf("reading column %s: %w", c.leaf.DottedPath(), err)
+	}
+	return data.slots()
+}
+
+// LogicalValues is like Values but converts each value to the column's
+// logical type as described for ConvertValue.
+func (c *ColumnChunkReader) LogicalValues() ([]interface{}, error) {
+	values, err := c.Values()
+	if err != nil {
+		return nil, err
+	}
+	return convertValues(c.leaf.Element, values)
+}
+
+// BoolValues decodes a BOOLEAN column.
+func (c *ColumnChunkReader) BoolValues() ([]bool, error) {
+	return typedValues[bool](c, 0)
+}
+
+// Int32Values decodes an INT32 column.
+func (c *ColumnChunkReader) Int32Values() ([]int32, error) {
+	return typedValues[int32](c, 1)
+}
+
+// Int64Values decodes an INT64 column.
+func (c *ColumnChunkReader) Int64Values() ([]int64, error) {
+	return typedValues[int64](c, 2)
+}
+
+// Int96Values decodes an INT96 column.
+func (c *ColumnChunkReader) Int96Values() ([]Int96, error) {
+	return typedValues[Int96](c, 3)
+}
+
+// FloatValues decodes a FLOAT column.
+func (c *ColumnChunkReader) FloatValues() ([]float32, error) {
+	return typedValues[float32](c, 4)
+}
+
+// DoubleValues decodes a DOUBLE column.
+func (c *ColumnChunkReader) DoubleValues() ([]float64, error) {
+	return typedValues[float64](c, 5)
+}
+
+// StringValues decodes a BYTE_ARRAY column.
+func (c *ColumnChunkReader) StringValues() ([]string, error) {
+	return typedValues[string](c, 6)
+}
+
+// FixedLenByteArrayValues decodes a FIXED_LEN_BYTE_ARRAY column. Each value is
+// a string of exactly the schema's type_length bytes.
+func (c *ColumnChunkReader) FixedLenByteArrayValues() ([]string, error) {
+	return typedValues[string](c, 7)
+}
+
+func typedValues[T any](c *ColumnChunkReader, physicalType int32) ([]T, error) {
+	if c.leaf.Element.Type != physicalType {
+		return nil, fmt.Errorf("column %s has type %s, not %s", c.leaf.Element.Name, TypeName(c.leaf.Element.Type), TypeName(physicalType))
+	}
+
+	values, err := c.Values()
+	if err != nil {
+		return nil, err
+	}
+
+	// NULLs decode as the zero value; use Values to tell them apart.
+	out := make([]T, 0, len(values))
+	for _, v := range values {
+		if v == nil {
+			var zero T
+			out = append(out, zero)
+			continue
+		}
+		tv, ok := v.(T)
+		if !ok {
+			return nil, fmt.Errorf("column %s: unexpected value of type %T", c.leaf.Element.Name, v)
+		}
+		out = append(out, tv)
+	}
+	return out, nil
+}
+
+// columnData is the decoded content of a column chunk: the dense non-null
+// values plus one repetition and definition level per slot.
+type columnData struct {
+	leaf             *SchemaNode
+	values           []interface{}
+	repetitionLevels []uint32
+	definitionLevels []uint32
+}
+
+// appendPage adds the output of a page decoder. Missing levels are filled in:
+// no repetition levels means every slot starts a new record, no definition
+// levels means every slot holds a value.
+func (d *columnData) appendPage(values []interface{}, repetitionLevels, definitionLevels []uint32) {
+	numSlots := len(values)
+	if definitionLevels != nil {
+		numSlots = len(definitionLevels)
+	} else if repetitionLevels != nil {
+		numSlots = len(repetitionLevels)
+	}
+
+	d.values = append(d.values, values...)
+	if repetitionLevels != nil {
+		d.repetitionLevels = append(d.repetitionLevels, repetitionLevels...)
+	} else {
+		d.repetitionLevels = append(d.repetitionLevels, make([]uint32, numSlots)...)
+	}
+	if definitionLevels != nil {
+		d.definitionLevels = append(d.definitionLevels, definitionLevels...)
+	} else {
+		for i := 0; i < numSlots; i++ {
+			d.definitionLevels = append(d.definitionLevels, uint32(d.leaf.MaxDefinitionLevel))
+		}
+	}
+}
+
+// slots spreads the dense non-null values over one entry per level slot,
+// leaving nil where the definition level is below the maximum.
+func (d *columnData) slots() ([]interface{}, error) {
+	out := make([]interface{}, len(d.definitionLevels))
+	next := 0
+	for i, level := range d.definitionLevels {
+		if level != uint32(d.leaf.MaxDefinitionLevel) {
+			continue
+		}
+		if next >= len(d.values) {
+			return nil, fmt.Errorf("column %s: definition levels reference more than %d values", d.leaf.DottedPath(), len(d.values))
+		}
+		out[i] = d.values[next]
+		next++
+	}
+	return out, nil
+}
+
+// numRows returns the number of rows in d: the slots with repetition level 0.
+func (d *columnData) numRows() int {
+	rows := 0
+	for _, level := range d.repetitionLevels {
+		if level == 0 {
+			rows++
+		}
+	}
+	return rows
+}
+
+// PageInfo describes one page of a column chunk as stored in the file.
+type PageInfo struct {
+	Header   *PageHeader
+	Offset   int64 // file offset of the page header
+	Checksum ChecksumStatus
+}
+
+// Pages returns every page of the chunk in file order, without decoding them.
+// Page CRCs are always checked here, whatever the checksum mode, and mismatches
+// are reported in PageInfo.Checksum rather than as errors.
+func (c *ColumnChunkReader) Pages() ([]PageInfo, error) {
+	pages, err := newPageReader(c.r, c.chunk)
+	if err != nil {
+		return nil, err
+	}
+
+	var infos []PageInfo
+	for {
+		offset := pages.offset
+		header, body, err := pages.next()
+		if errors.Is(err, io.EOF) {
+			return infos, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+		status, _ := verifyPageChecksum(header, body, offset)
+		infos = append(infos, PageInfo{Header: header, Offset: offset, Checksum: status})
+	}
+}
+
+// pageReader iterates over the pages of a column chunk.
+type pageReader struct {
+	rbuf *bufio.Reader
+	// offset is the file offset of the next page header.
+	offset int64
+}
+
+func newPageReader(r io.ReaderAt, chunk ColumnChunk) (*pageReader, error) {
+	if chunk.MetaData == nil {
+		return nil, fmt.Errorf("no metadata for column chunk")
+	}
+	return newPageReaderAt(r, chunkStart(chunk), chunk.MetaData.TotalCompressedSize), nil
+}
+
+// newPageReaderAt returns a reader for the pages in the size bytes at offset.
+func newPageReaderAt(r io.ReaderAt, offset, size int64) *pageReader {
+	section := io.NewSectionReader(r, offset, size)
+	return &pageReader{rbuf: bufio.NewReaderSize(section, 64*1024), offset: offset}
+}
+
+// chunkStart returns the file offset of the first page of a chunk, which is
+// its dictionary page if it has one.
+func chunkStart(chunk ColumnChunk) int64 {
+	if chunk.MetaData.DictionaryPageOffset != nil && *chunk.MetaData.DictionaryPageOffset != 0 {
+		return *chunk.MetaData.DictionaryPageOffset
+	}
+	return chunk.MetaData.DataPageOffset
+}
+
+// next returns the next page header, parsed with the Kaitai-generated Thrift
+// Compact parser, and the page body as stored in the file. It returns io.EOF
+// at the end of the chunk.
+func (p *pageReader) next() (*PageHeader, []byte, error) {
+	headerStruct, headerSize, err := parseCompactStructFromBufio(p.rbuf, 64*1024)
+	if err != nil {
+		if errors.Is(err, io.EOF) {
+			return nil, nil, io.EOF
+		}
+		return nil, nil, fmt.Errorf("parsing page header at offset %d: %v", p.offset, err)
+	}
+
+	header, err := decodePageHeader(headerStruct)
+	if err != nil {
+		return nil, nil, fmt.Errorf("decoding page header at offset %d: %v", p.offset, err)
+	}
+	if header.CompressedPageSize < 0 {
+		return nil, nil, fmt.Errorf("invalid compressed page size: %d", header.CompressedPageSize)
+	}
+
+	body := make([]byte, header.CompressedPageSize)
+	if _, err := io.ReadFull(p.rbuf, body); err != nil {
+		return nil, nil, fmt.Errorf("reading page body of %d bytes: %v", header.CompressedPageSize, err)
+	}
+	p.offset += int64(headerSize) + int64(len(body))
+	return header, body, nil
+}
+
+// readColumnChunk reads and decodes all pages of a column chunk.
+// Page CRCs are verified according to opts.
+func readColumnChunk(r io.ReaderAt, chunk ColumnChunk, leaf *SchemaNode, opts options) (*columnData, error) {
+	pages, err := newPageDecoder(r, chunk, leaf, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	data := &columnData{leaf: leaf}
+	totalValues := chunk.MetaData.NumValues
+
+	for int64(len(data.definitionLevels)) < totalValues {
+		values, repetitionLevels, definitionLevels, err := pages.next()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+		data.appendPage(values, repetitionLevels, definitionLevels)
+	}
+
+	if int64(len(data.definitionLevels)) < totalValues {
+		return nil, fmt.Errorf("chunk ends %d of %d levels short: %w", totalValues-int64(len(data.definitionLevels)), totalValues, io.ErrUnexpectedEOF)
+	}
+	return data, nil
+}
+
+// pageDecoder decodes the data pages of a column chunk one at a time. A
+// dictionary page is decoded on the way and kept for the pages after it.
+type pageDecoder struct {
+	r          io.ReaderAt
+	pages      *pageReader
+	chunk      ColumnChunk
+	leaf       *SchemaNode
+	opts       options
+	typeLength int
+	dictionary []interface{}
+	// pageBuf holds the decompressed contents of one page at a time.
+	pageBuf []byte
+}
+
+func newPageDecoder(r io.ReaderAt, chunk ColumnChunk, leaf *SchemaNode, opts options) (*pageDecoder, error) {
+	pages, err := newPageReader(r, chunk)
+	if err != nil {
+		return nil, err
+	}
+
+	typeLength := 0
+	if leaf.Element.TypeLength != nil {
+		typeLength = int(*leaf.Element.TypeLength)
+	}
+	return &pageDecoder{r: r, pages: pages, chunk: chunk, leaf: leaf, opts: opts, typeLength: typeLength}, nil
+}
+
+// next decodes the next data page and returns its dense values and levels as
+// returned by the page parsers. It returns io.EOF at the end of the chunk.
+func (d *pageDecoder) next() ([]interface{}, []uint32, []uint32, error) {
+	schema := d.leaf.Element
+	codec := d.chunk.MetaData.Codec
+	maxDefinitionLevel := byte(d.leaf.MaxDefinitionLevel)
+	maxRepetitionLevel := byte(d.leaf.MaxRepetitionLevel)
+
+	for {
+		offset := d.pages.offset
+		header, body, err := d.readPage()
+		if err != nil {
+			return nil, nil, nil, err
+		}
+
+		switch {
+		case header.Type == 2 && header.DictionaryPageHeader != nil: // DICTIONARY_PAGE
+			if err := d.decodeDictionary(header, body); err != nil {
+				return nil, nil, nil, err
+			}
+
+		case header.Type == 0 && header.DataPageHeader != nil: // DATA_PAGE
+			pageData, err := decompressData(d.pageBuf, body, codec, int(header.UncompressedPageSize))
+			if err != nil {
+				return nil, nil, nil, fmt.Errorf("decompressing data page: %v", err)
+			}
+			values, repetitionLevels, definitionLevels, err := parseDataPageWithEncoding(pageData, header.DataPageHeader, schema.Type, d.typeLength, maxRepetitionLevel, maxDefinitionLevel, d.dictionary)
+			if err != nil {
+				return nil, nil, nil, fmt.Errorf("data page at offset %d: %w", offset, err)
+			}
+			return values, repetitionLevels, definitionLevels, nil
+
+		case header.Type == 3 && header.DataPageHeaderV2 != nil: // DATA_PAGE_V2
+			values, repetitionLevels, definitionLevels, err := parseDataPageV2(body, d.pageBuf, header.DataPageHeaderV2, codec, int(header.UncompressedPageSize), schema.Type, d.typeLength, maxRepetitionLevel, maxDefinitionLevel, d.dictionary)
+			if err != nil {
+				return nil, nil, nil, fmt.Errorf("data page at offset %d: %w", offset, err)
+			}
+			return values, repetitionLevels, definitionLevels, nil
+		}
+	}
+}
+
+// readPage reads the next page, verifies its CRC according to the options and
+// makes room in pageBuf for its decompressed contents.
+func (d *pageDecoder) readPage() (*PageHeader, []byte, error) {
+	offset := d.pages.offset
+	header, body, err := d.pages.next()
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if d.opts.checksum != ChecksumOff {
+		if _, err := verifyPageChecksum(header, body, offset); err != nil {
+			if d.opts.checksum == ChecksumStrict {
+				return nil, nil, err
+			}
+			d.opts.warn(fmt.Errorf("column %s: %v", d.leaf.DottedPath(), err))
+		}
+	}
+
+	if size := int(header.UncompressedPageSize); cap(d.pageBuf) < size {
+		d.pageBuf = make([]byte, 0, size)
+	}
+	return header, body, nil
+}
+
+func (d *pageDecoder) decodeDictionary(header *PageHeader, body []byte) error {
+	pageData, err := decompressData(d.pageBuf, body, d.chunk.MetaData.Codec, int(header.UncompressedPageSize))
+	if err != nil {
+		return fmt.Errorf("decompressing dictionary page: %v", err)
+	}
+	d.dictionary, err = decodeDictionaryPage(pageData, d.leaf.Element.Type, d.typeLength, header.DictionaryPageHeader)
+	return err
+}
+
+// seek positions the decoder at the page at offset. If the chunk starts with a
+// dictionary page before it, that page is decoded first.
+func (d *pageDecoder) seek(offset int64) error {
+	start := chunkStart(d.chunk)
+	end := start + d.chunk.MetaData.TotalCompressedSize
+	if offset < start || offset >= end {
+		return fmt.Errorf("page offset %d outside column chunk [%d, %d)", offset, start, end)
+	}
+
+	if offset > start && d.dictionary == nil {
+		header, body, err := d.readPage()
+		if err != nil {
+			return err
+		}
+		if header.Type == 2 && header.DictionaryPageHeader != nil { // DICTIONARY_PAGE
+			if err := d.decodeDictionary(header, body); err != nil {
+				return err
+			}
+		}
+	}
+
+	d.pages = newPageReaderAt(d.r, offset, end-offset)
+	return nil
+}
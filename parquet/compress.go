@@ -0,0 +1,209 @@
+package parquet
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"sync"
+
+	"github.com/andybalholm/brotli"
+	"github.com/klauspost/compress/gzip"
+	"github.com/klauspost/compress/snappy"
+	"github.com/klauspost/compress/zstd"
+)
+
+// Decompressor decompresses the body of a page. dst is an empty buffer with
+// the page's uncompressed_page_size as capacity; implementations should append
+// to it and return the result, which the reader checks against that size.
+// Decompressors are called from concurrent readers and must be safe for
+// concurrent use.
+type Decompressor interface {
+	Decompress(dst, src []byte) ([]byte, error)
+}
+
+// DecompressorFunc adapts an ordinary function to the Decompressor interface.
+type DecompressorFunc func(dst, src []byte) ([]byte, error)
+
+// Decompress calls f(dst, src).
+func (f DecompressorFunc) Decompress(dst, src []byte) ([]byte, error) {
+	return f(dst, src)
+}
+
+var (
+	codecsMu sync.RWMutex
+	codecs   = map[int32]Decompressor{
+		0: DecompressorFunc(decompressUncompressed), // UNCOMPRESSED
+		1: DecompressorFunc(decompressSnappy),       // SNAPPY
+		2: DecompressorFunc(decompressGzip),         // GZIP
+		3: DecompressorFunc(decompressLZO),          // LZO
+		4: DecompressorFunc(decompressBrotli),       // BROTLI
+		5: DecompressorFunc(decompressLZ4Hadoop),    // LZ4 (deprecated, Hadoop framing)
+		6: DecompressorFunc(decompressZstd),         // ZSTD
+		7: DecompressorFunc(decompressLZ4Raw),       // LZ4_RAW
+	}
+)
+
+// RegisterCodec installs d as the decompressor for the CompressionCodec id,
+// replacing any built-in or previously registered one. A nil d removes the
+// codec. The built-in LZO (3) decompressor only reports that LZO is
+// unsupported.
+func RegisterCodec(id int32, d Decompressor) {
+	codecsMu.Lock()
+	defer codecsMu.Unlock()
+	if d == nil {
+		delete(codecs, id)
+		return
+	}
+	codecs[id] = d
+}
+
+func lookupCodec(id int32) (Decompressor, bool) {
+	codecsMu.RLock()
+	defer codecsMu.RUnlock()
+	d, ok := codecs[id]
+	return d, ok
+}
+
+// decompressData decompresses a page compressed with the given CompressionCodec
+// and checks that it inflates to exactly uncompressedSize bytes, the
+// uncompressed_page_size of the page header. The output goes to dst when its
+// capacity allows, so that one buffer can be reused across pages.
+func decompressData(dst, data []byte, codec int32, uncompressedSize int) ([]byte, error) {
+	if uncompressedSize < 0 {
+		return nil, fmt.Errorf("invalid uncompressed page size: %d", uncompressedSize)
+	}
+	d, ok := lookupCodec(codec)
+	if !ok {
+		return nil, fmt.Errorf("unsupported compression codec: %d", codec)
+	}
+
+	if cap(dst) < uncompressedSize {
+		dst = make([]byte, 0, uncompressedSize)
+	}
+	out, err := d.Decompress(dst[:0:uncompressedSize], data)
+	if err != nil {
+		return nil, fmt.Errorf("codec %d: %v", codec, err)
+	}
+	if len(out) != uncompressedSize {
+		return nil, fmt.Errorf("codec %d: decompressed to %d bytes, expected %d", codec, len(out), uncompressedSize)
+	}
+	return out, nil
+}
+
+func decompressUncompressed(_, src []byte) ([]byte, error) {
+	return src, nil
+}
+
+func decompressSnappy(dst, src []byte) ([]byte, error) {
+	// snappy.Decode only writes into dst when its length is large enough.
+	return snappy.Decode(dst[:cap(dst)], src)
+}
+
+var gzipReaders sync.Pool // of *gzip.Reader
+
+func decompressGzip(dst, src []byte) ([]byte, error) {
+	zr, _ := gzipReaders.Get().(*gzip.Reader)
+	var err error
+	if zr == nil {
+		zr, err = gzip.NewReader(bytes.NewReader(src))
+	} else {
+		err = zr.Reset(bytes.NewReader(src))
+	}
+	if err != nil {
+		return nil, err
+	}
+	defer gzipReaders.Put(zr)
+	return readLimited(dst, zr)
+}
+
+var brotliReaders sync.Pool // of *brotli.Reader
+
+func decompressBrotli(dst, src []byte) ([]byte, error) {
+	br, _ := brotliReaders.Get().(*brotli.Reader)
+	if br == nil {
+		br = brotli.NewReader(bytes.NewReader(src))
+	} else if err := br.Reset(bytes.NewReader(src)); err != nil {
+		return nil, err
+	}
+	defer brotliReaders.Put(br)
+	return readLimited(dst, br)
+}
+
+// zstdDecoder is shared by all ZSTD pages: DecodeAll is safe for concurrent use
+// and pools its block decoders internally. It is created on first use.
+var (
+	zstdOnce       sync.Once
+	zstdDecoder    *zstd.Decoder
+	zstdDecoderErr error
+)
+
+func decompressZstd(dst, src []byte) ([]byte, error) {
+	zstdOnce.Do(func() {
+		zstdDecoder, zstdDecoderErr = zstd.NewReader(nil, zstd.WithDecoderConcurrency(0))
+	})
+	if zstdDecoderErr != nil {
+		return nil, fmt.Errorf("creating zstd decoder: %v", zstdDecoderErr)
+	}
+	return zstdDecoder.DecodeAll(src, dst)
+}
+
+// decompressLZO stands in for LZO, which has no decoder here; RegisterCodec
+// can install one.
+func decompressLZO(_, _ []byte) ([]byte, error) {
+	return nil, errors.New("LZO compression is not supported")
+}
+
+func decompressLZ4Raw(dst, src []byte) ([]byte, error) {
+	return decodeLZ4Block(dst, src)
+}
+
+// readLimited appends a stream that should fill dst's capacity to dst. One
+// extra byte is allowed through so that oversized output is detected instead of
+// truncated.
+func readLimited(dst []byte, r io.Reader) ([]byte, error) {
+	buf := bytes.NewBuffer(dst)
+	if _, err := io.Copy(buf, io.LimitReader(r, int64(cap(dst)-len(dst))+1)); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// decompressLZ4Hadoop decodes the deprecated LZ4 codec. Writers disagree on
+// its framing: parquet-mr emits Hadoop's framing (a sequence of blocks, each
+// preceded by big-endian uncompressed and compressed sizes), while older
+// parquet-cpp wrote a bare LZ4 block. The Hadoop framing is tried first and a
+// bare block is the fallback, like other readers do.
+func decompressLZ4Hadoop(dst, src []byte) ([]byte, error) {
+	if out, ok := decodeLZ4HadoopFrames(dst, src); ok {
+		return out, nil
+	}
+	return decodeLZ4Block(dst, src)
+}
+
+// decodeLZ4HadoopFrames decodes Hadoop-framed LZ4 into dst, reporting false if
+// src does not parse as such a stream filling exactly dst's capacity.
+func decodeLZ4HadoopFrames(dst, src []byte) ([]byte, bool) {
+	n := len(dst)
+	for len(src) >= 8 {
+		blockSize := int(binary.BigEndian.Uint32(src[0:4]))
+		compressedSize := int(binary.BigEndian.Uint32(src[4:8]))
+		src = src[8:]
+		if compressedSize > len(src) || blockSize > cap(dst)-n {
+			return nil, false
+		}
+
+		// Each block is decoded in place into its own window of dst.
+		block, err := decodeLZ4Block(dst[n:n:n+blockSize], src[:compressedSize])
+		if err != nil || len(block) != blockSize {
+			return nil, false
+		}
+		n += blockSize
+		src = src[compressedSize:]
+	}
+	if len(src) != 0 || n != cap(dst) {
+		return nil, false
+	}
+	return dst[:n], true
+}
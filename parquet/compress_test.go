@@ -0,0 +1,132 @@
+package parquet
+
+import (
+	"bytes"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/andybalholm/brotli"
+	"github.com/klauspost/compress/gzip"
+	"github.com/klauspost/compress/snappy"
+	"github.com/klauspost/compress/zstd"
+)
+
+func TestDecompressData(t *testing.T) {
+	page := []byte(strings.Repeat("parquet page ", 20))
+
+	var gz, br bytes.Buffer
+	zw := gzip.NewWriter(&gz)
+	zw.Write(page)
+	zw.Close()
+	bw := brotli.NewWriter(&br)
+	bw.Write(page)
+	bw.Close()
+	ze, err := zstd.NewWriter(nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	zs := ze.EncodeAll(page, nil)
+
+	tests := []struct {
+		name  string
+		codec int32
+		data  []byte
+	}{
+		{"uncompressed", 0, page},
+		{"snappy", 1, snappy.Encode(nil, page)},
+		{"gzip", 2, gz.Bytes()},
+		{"brotli", 4, br.Bytes()},
+		{"zstd", 6, zs},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := decompressData(nil, tt.data, tt.codec, len(page))
+			if err != nil {
+				t.Fatalf("decompressData: %v", err)
+			}
+			if !bytes.Equal(got, page) {
+				t.Errorf("got %q, want %q", got, page)
+			}
+			if _, err := decompressData(nil, tt.data, tt.codec, len(page)-1); err == nil {
+				t.Error("wrong uncompressed size: expected an error")
+			}
+		})
+	}
+}
+
+func TestDecompressDataUnsupported(t *testing.T) {
+	if _, err := decompressData(nil, []byte{0}, 3, 1); err == nil || !strings.Contains(err.Error(), "LZO") {
+		t.Errorf("LZO: got %v, want an LZO unsupported error", err)
+	}
+	if _, err := decompressData(nil, []byte{0}, 42, 1); err == nil {
+		t.Error("unknown codec: expected an error")
+	}
+}
+
+func TestDecompressDataReusesDst(t *testing.T) {
+	page := []byte("parquet page")
+	dst := make([]byte, 0, 64)
+	got, err := decompressData(dst, snappy.Encode(nil, page), 1, len(page))
+	if err != nil {
+		t.Fatalf("decompressData: %v", err)
+	}
+	if !bytes.Equal(got, page) || &got[0] != &dst[:1][0] {
+		t.Errorf("got %q in a new buffer, want %q in dst", got, page)
+	}
+}
+
+func TestRegisterCodec(t *testing.T) {
+	// A codec that stores the page bytes in reverse.
+	const codec = 100
+	RegisterCodec(codec, DecompressorFunc(func(dst, src []byte) ([]byte, error) {
+		for i := len(src) - 1; i >= 0; i-- {
+			dst = append(dst, src[i])
+		}
+		return dst, nil
+	}))
+	t.Cleanup(func() { RegisterCodec(codec, nil) })
+
+	// One PLAIN data page of a required INT32 column holding 1, 2, 3.
+	values := []byte{1, 0, 0, 0, 2, 0, 0, 0, 3, 0, 0, 0}
+	body := make([]byte, 0, len(values))
+	for i := len(values) - 1; i >= 0; i-- {
+		body = append(body, values[i])
+	}
+	var w compactWriter
+	w.begin()
+	w.i32(1, 0) // type: DATA_PAGE
+	w.i32(2, int32(len(values)))
+	w.i32(3, int32(len(body)))
+	w.structField(5, func() {
+		w.i32(1, 3) // num_values
+		w.i32(2, 0) // encoding: PLAIN
+		w.i32(3, 3) // definition_level_encoding: RLE
+		w.i32(4, 3) // repetition_level_encoding: RLE
+	})
+	w.end()
+	w.Write(body)
+
+	numChildren := int32(1)
+	_, leaves, err := buildSchemaTree([]SchemaElement{{Name: "schema", NumChildren: &numChildren}, testLeaf("v", 0, 1)})
+	if err != nil {
+		t.Fatalf("buildSchemaTree: %v", err)
+	}
+	chunk := ColumnChunk{MetaData: &ColumnMetaData{
+		Type: 1, Codec: codec, NumValues: 3, TotalCompressedSize: int64(w.Len()),
+	}}
+	r := bytes.NewReader(w.Bytes())
+
+	data, err := readColumnChunk(r, chunk, leaves[0], defaultOptions())
+	if err != nil {
+		t.Fatalf("readColumnChunk: %v", err)
+	}
+	if want := []interface{}{int32(1), int32(2), int32(3)}; !reflect.DeepEqual(data.values, want) {
+		t.Errorf("got %v, want %v", data.values, want)
+	}
+
+	RegisterCodec(codec, nil)
+	if _, err := readColumnChunk(r, chunk, leaves[0], defaultOptions()); err == nil {
+		t.Error("unregistered codec: expected an error")
+	}
+}
@@ -0,0 +1,139 @@
+package parquet
+
+import (
+	"errors"
+	"io"
+	"reflect"
+	"testing"
+)
+
+// The streams below are the examples of the Parquet encodings spec, with a
+// block of 128 values in 4 miniblocks of 32.
+
+// 1, 2, 3, 4, 5: every delta is the min delta 1, so all bit widths are 0.
+var deltaExample1 = []byte{
+	0x80, 0x01, 0x04, 0x05, 0x02, // header: block 128, 4 miniblocks, 5 values, first 1
+	0x02,                   // min delta 1
+	0x00, 0x00, 0x00, 0x00, // bit widths
+}
+
+// 7, 5, 3, 1, 2, 3, 4, 5: min delta -2, relative deltas 0 0 0 3 3 3 3.
+var deltaExample2 = append([]byte{
+	0x80, 0x01, 0x04, 0x08, 0x0e, // header: block 128, 4 miniblocks, 8 values, first 7
+	0x03,                   // min delta -2
+	0x02, 0x00, 0x00, 0x00, // bit widths
+	0xc0, 0x3f, // 2-bit deltas, LSB first
+}, make([]byte, 6)...) // padding to 32 values
+
+func TestDecodeDeltaBinaryPacked(t *testing.T) {
+	tests := []struct {
+		name      string
+		data      []byte
+		dataType  int32
+		numValues int
+		want      []interface{}
+	}{
+		{"spec example 1", deltaExample1, 2, 5, []interface{}{int64(1), int64(2), int64(3), int64(4), int64(5)}},
+		{"spec example 2", deltaExample2, 2, 8, []interface{}{int64(7), int64(5), int64(3), int64(1), int64(2), int64(3), int64(4), int64(5)}},
+		{"int32", deltaExample2, 1, 3, []interface{}{int32(7), int32(5), int32(3)}},
+		{"first value only", []byte{0x80, 0x01, 0x04, 0x01, 0x01}, 1, 1, []interface{}{int32(-1)}},
+		{"empty", []byte{0x80, 0x01, 0x04, 0x00, 0x00}, 2, 0, []interface{}{}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := decodeDeltaBinaryPacked(tt.data, tt.dataType, tt.numValues)
+			if err != nil {
+				t.Fatalf("decodeDeltaBinaryPacked: %v", err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("got %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestDecodeDeltaBinaryPackedErrors(t *testing.T) {
+	tests := []struct {
+		name string
+		data []byte
+		eof  bool
+	}{
+		{"missing bit widths", deltaExample2[:7], true},
+		{"short miniblock", deltaExample2[:len(deltaExample2)-1], true},
+		{"block size not a multiple of 128", []byte{0x40, 0x04, 0x01, 0x00}, false},
+		{"miniblock not a multiple of 32", []byte{0x80, 0x01, 0x08, 0x01, 0x00}, false},
+		{"bit width over 64", []byte{0x80, 0x01, 0x04, 0x02, 0x00, 0x00, 65, 0, 0, 0}, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, _, err := decodeDeltaBinaryPackedInt64(tt.data)
+			if err == nil {
+				t.Fatal("expected an error")
+			}
+			if tt.eof && !errors.Is(err, io.ErrUnexpectedEOF) {
+				t.Errorf("got %v, want io.ErrUnexpectedEOF", err)
+			}
+		})
+	}
+}
+
+// "Hello", "World", "Foobar", "ABCDEF": lengths 5 5 6 6, min delta 0,
+// relative deltas 0 1 0.
+var deltaLengthExample = append([]byte{
+	0x80, 0x01, 0x04, 0x04, 0x0a, // header: 4 values, first 5
+	0x00,                   // min delta 0
+	0x01, 0x00, 0x00, 0x00, // bit widths
+	0x02, 0x00, 0x00, 0x00, // 1-bit deltas
+}, "HelloWorldFoobarABCDEF"...)
+
+func TestDecodeDeltaLengthByteArray(t *testing.T) {
+	got, err := decodeDeltaLengthByteArray(deltaLengthExample, 6, 4)
+	if err != nil {
+		t.Fatalf("decodeDeltaLengthByteArray: %v", err)
+	}
+	want := []interface{}{"Hello", "World", "Foobar", "ABCDEF"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("got %v, want %v", got, want)
+	}
+
+	if _, err := decodeDeltaLengthByteArray(deltaLengthExample[:len(deltaLengthExample)-1], 6, 4); err == nil {
+		t.Error("truncated values: expected an error")
+	}
+	if _, err := decodeDeltaLengthByteArray(deltaLengthExample, 6, 5); err == nil {
+		t.Error("too few values: expected an error")
+	}
+}
+
+func TestDecodeDeltaByteArray(t *testing.T) {
+	// "axis", "axle", "babble", "babyhood": prefix lengths 0 2 0 3 and
+	// suffixes "axis", "le", "babble", "yhood".
+	prefixes := append([]byte{
+		0x80, 0x01, 0x04, 0x04, 0x00, // header: 4 values, first 0
+		0x03,                   // min delta -2
+		0x03, 0x00, 0x00, 0x00, // bit widths
+		0x44, 0x01, // 3-bit deltas 4 0 5
+	}, make([]byte, 10)...)
+	suffixes := append(append([]byte{
+		0x80, 0x01, 0x04, 0x04, 0x08, // header: 4 values, first 4
+		0x03,                   // min delta -2
+		0x03, 0x00, 0x00, 0x00, // bit widths
+		0x70, 0x00, // 3-bit deltas 0 6 1
+	}, make([]byte, 10)...), "axislebabbleyhood"...)
+	data := append(prefixes, suffixes...)
+
+	got, err := decodeDeltaByteArray(data, 6, 4)
+	if err != nil {
+		t.Fatalf("decodeDeltaByteArray: %v", err)
+	}
+	want := []interface{}{"axis", "axle", "babble", "babyhood"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("got %v, want %v", got, want)
+	}
+
+	// A prefix longer than the previous value is invalid: prefix length 1
+	// and suffix "x" for the first value.
+	bad := []byte{0x80, 0x01, 0x04, 0x01, 0x02, 0x80, 0x01, 0x04, 0x01, 0x02, 'x'}
+	if _, err := decodeDeltaByteArray(bad, 6, 1); err == nil {
+		t.Error("prefix longer than previous value: expected an error")
+	}
+}
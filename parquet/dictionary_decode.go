@@ -0,0 +1,58 @@
+package parquet
+
+import (
+	"fmt"
+)
+
+// decodeDictionaryPage decodes the values of a DICTIONARY_PAGE. Dictionary
+// values are always PLAIN encoded; PLAIN_DICTIONARY is the legacy name for it.
+func decodeDictionaryPage(data []byte, dataType int32, typeLength int, header *DictionaryPageHeader) ([]interface{}, error) {
+	encoding, numValues := header.Encoding, header.NumValues
+	if encoding != 0 && encoding != 2 {
+		return nil, fmt.Errorf("unsupported dictionary page encoding: %d", encoding)
+	}
+	if numValues < 0 {
+		return nil, fmt.Errorf("invalid dictionary page value count: %d", numValues)
+	}
+
+	values, err := decodePlainValues(data, dataType, typeLength, int(numValues))
+	if err != nil {
+		return nil, fmt.Errorf("decoding dictionary page: %w", err)
+	}
+	if len(values) != int(numValues) {
+		return nil, fmt.Errorf("dictionary page has %d values, header declares %d", len(values), numValues)
+	}
+	return values, nil
+}
+
+// decodeDictionaryIndices decodes RLE_DICTIONARY (and PLAIN_DICTIONARY) data.
+// Format: [1 byte bit width] [RLE/bit-packed hybrid indices, no length prefix].
+func decodeDictionaryIndices(data []byte, numValues int, dictionary []interface{}) ([]interface{}, error) {
+	if dictionary == nil {
+		return nil, fmt.Errorf("dictionary-encoded page without a dictionary page")
+	}
+	if numValues == 0 {
+		return make([]interface{}, 0), nil
+	}
+	if len(data) < 1 {
+		return nil, fmt.Errorf("insufficient data for dictionary indices")
+	}
+
+	bitWidth := uint(data[0])
+	indices, _, err := decodeRLEHybrid(data[1:], numValues, bitWidth)
+	if err != nil {
+		return nil, fmt.Errorf("decoding dictionary indices: %v", err)
+	}
+	if len(indices) < numValues {
+		return nil, fmt.Errorf("decoded %d dictionary indices, expected %d", len(indices), numValues)
+	}
+
+	values := make([]interface{}, 0, numValues)
+	for _, idx := range indices {
+		if int(idx) >= len(dictionary) {
+			return nil, fmt.Errorf("dictionary index %d out of range [0, %d)", idx, len(dictionary))
+		}
+		values = append(values, dictionary[idx])
+	}
+	return values, nil
+}
@@ -0,0 +1,94 @@
+package parquet
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestDecodeDictionaryPage(t *testing.T) {
+	data := []byte{10, 0, 0, 0, 20, 0, 0, 0, 30, 0, 0, 0}
+	for _, encoding := range []int32{0, 2} { // PLAIN, PLAIN_DICTIONARY
+		got, err := decodeDictionaryPage(data, 1, 0, &DictionaryPageHeader{NumValues: 3, Encoding: encoding})
+		if err != nil {
+			t.Fatalf("encoding %d: %v", encoding, err)
+		}
+		if want := []interface{}{int32(10), int32(20), int32(30)}; !reflect.DeepEqual(got, want) {
+			t.Errorf("encoding %d: got %v, want %v", encoding, got, want)
+		}
+	}
+
+	if _, err := decodeDictionaryPage(data, 1, 0, &DictionaryPageHeader{NumValues: 3, Encoding: 8}); err == nil {
+		t.Error("RLE_DICTIONARY dictionary page: expected an error")
+	}
+	if _, err := decodeDictionaryPage(data[:8], 1, 0, &DictionaryPageHeader{NumValues: 3}); err == nil {
+		t.Error("short dictionary page: expected an error")
+	}
+}
+
+func TestDecodeDictionaryIndices(t *testing.T) {
+	dictionary := []interface{}{"a", "b", "c"}
+	tests := []struct {
+		name      string
+		data      []byte
+		numValues int
+		want      []interface{}
+	}{
+		// Bit width 2, one bit-packed group of 8 indices 0 1 2 1 0 0 0 0.
+		{"bit-packed", []byte{0x02, 0x03, 0x64, 0x00}, 5, []interface{}{"a", "b", "c", "b", "a"}},
+		// Bit width 2, a run of 4 times index 2.
+		{"rle run", []byte{0x02, 0x08, 0x02}, 4, []interface{}{"c", "c", "c", "c"}},
+		{"rle then bit-packed", []byte{0x02, 0x04, 0x01, 0x03, 0x24, 0x00}, 4, []interface{}{"b", "b", "a", "b"}},
+		{"no values", nil, 0, []interface{}{}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := decodeDictionaryIndices(tt.data, tt.numValues, dictionary)
+			if err != nil {
+				t.Fatalf("decodeDictionaryIndices: %v", err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("got %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestDecodeDictionaryIndicesErrors(t *testing.T) {
+	tests := []struct {
+		name       string
+		data       []byte
+		numValues  int
+		dictionary []interface{}
+	}{
+		{"no dictionary", []byte{0x02, 0x08, 0x02}, 4, nil},
+		{"index out of range", []byte{0x02, 0x08, 0x03}, 4, []interface{}{"a", "b", "c"}},
+		{"missing bit width", nil, 1, []interface{}{"a"}},
+		{"too few indices", []byte{0x02, 0x04, 0x01}, 4, []interface{}{"a", "b"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got, err := decodeDictionaryIndices(tt.data, tt.numValues, tt.dictionary); err == nil {
+				t.Fatalf("got %v, expected an error", got)
+			}
+		})
+	}
+}
+
+func TestParseDataPageEncodings(t *testing.T) {
+	dictionary := []interface{}{int32(7), int32(9)}
+
+	// A required INT32 page of 3 values, RLE_DICTIONARY encoded with bit
+	// width 1: one bit-packed group holding indices 1 0 1.
+	got, _, _, err := parseDataPageWithEncoding([]byte{0x01, 0x03, 0x05}, &DataPageHeader{NumValues: 3, Encoding: 8}, 1, 0, 0, 0, dictionary)
+	if err != nil {
+		t.Fatalf("RLE_DICTIONARY: %v", err)
+	}
+	if want := []interface{}{int32(9), int32(7), int32(9)}; !reflect.DeepEqual(got, want) {
+		t.Errorf("RLE_DICTIONARY: got %v, want %v", got, want)
+	}
+
+	// Encodings without a decoder are rejected rather than read as PLAIN.
+	if got, _, _, err := parseDataPageWithEncoding([]byte{1, 0, 0, 0}, &DataPageHeader{NumValues: 1, Encoding: 4}, 1, 0, 0, 0, nil); err == nil {
+		t.Errorf("encoding 4: got %v, expected an error", got)
+	}
+}
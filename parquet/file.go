@@ -0,0 +1,218 @@
+package parquet
+
+import (
+	"fmt"
+	"io"
+	"slices"
+	"strings"
+
+	"github.com/kaitai-io/kaitai_struct_go_runtime/kaitai"
+	"kaitai_parquet/kaitai_gen"
+)
+
+// File is an opened Parquet file. The footer is decoded once by OpenFile;
+// column chunks are read lazily from the underlying io.ReaderAt.
+type File struct {
+	r        io.ReaderAt
+	size     int64
+	metadata *FileMetadata
+	schema   *SchemaNode
+	leaves   []*SchemaNode
+	// selected marks the leaves chosen by WithColumns; nil selects all.
+	selected []bool
+	opts     options
+}
+
+// OpenFile checks the leading and trailing magic of a Parquet file of the given
+// size and decodes its footer via the Kaitai-generated parsers.
+func OpenFile(r io.ReaderAt, size int64, opts ...Option) (*File, error) {
+	o := defaultOptions()
+	for _, opt := range opts {
+		opt(&o)
+	}
+
+	// kaitai.NewStream needs an io.ReadSeeker; a section reader provides one over r.
+	stream := kaitai.NewStream(io.NewSectionReader(r, 0, size))
+	pq := kaitai_gen.NewParquet()
+	if err := pq.Read(stream, nil, pq); err != nil {
+		return nil, fmt.Errorf("error parsing parquet file: %v", err)
+	}
+
+	if pq.Magic != "PAR1" {
+		return nil, fmt.Errorf("invalid magic at start: %s", pq.Magic)
+	}
+
+	// Read footer via Kaitai-generated Parquet parser (as Thrift Compact AST).
+	footerStruct, err := pq.FooterThrift()
+	if err != nil {
+		return nil, fmt.Errorf("error reading footer: %v", err)
+	}
+
+	magicEnd, err := pq.MagicEnd()
+	if err != nil {
+		return nil, fmt.Errorf("error reading end magic: %v", err)
+	}
+	if magicEnd != "PAR1" {
+		return nil, fmt.Errorf("invalid magic at end: %s", magicEnd)
+	}
+
+	metadata, err := decodeFileMetaData(footerStruct)
+	if err != nil {
+		return nil, fmt.Errorf("error extracting metadata: %v", err)
+	}
+
+	schema, leaves, err := buildSchemaTree(metadata.Schema)
+	if err != nil {
+		return nil, fmt.Errorf("error building schema tree: %v", err)
+	}
+
+	var selected []bool
+	if o.project {
+		if selected, err = selectColumns(leaves, o.columns); err != nil {
+			return nil, err
+		}
+	}
+
+	return &File{r: r, size: size, metadata: metadata, schema: schema, leaves: leaves, selected: selected, opts: o}, nil
+}
+
+// selectColumns marks the leaves named by columns, either by their own dotted
+// path or by that of an enclosing group, and the keys of the maps they are in.
+func selectColumns(leaves []*SchemaNode, columns []string) ([]bool, error) {
+	selected := make([]bool, len(leaves))
+	for _, column := range columns {
+		path := strings.Split(column, ".")
+		found := false
+		for j, leaf := range leaves {
+			if len(leaf.Path) >= len(path) && slices.Equal(leaf.Path[:len(path)], path) {
+				selected[j] = true
+				found = true
+			}
+		}
+		if !found {
+			return nil, fmt.Errorf("column %q not found in schema", column)
+		}
+	}
+
+	// Map entries cannot be assembled without their keys.
+	for j, leaf := range leaves {
+		if !selected[j] {
+			continue
+		}
+		for node := leaf.Parent; node != nil; node = node.Parent {
+			if isMapNode(node) {
+				selectLeaves(node.Children[0].Children[0], selected)
+			}
+		}
+	}
+	return selected, nil
+}
+
+func selectLeaves(node *SchemaNode, selected []bool) {
+	if node.IsLeaf() {
+		selected[node.ColumnIndex] = true
+	}
+	for _, child := range node.Children {
+		selectLeaves(child, selected)
+	}
+}
+
+// Metadata returns the decoded FileMetaData of the file.
+func (f *File) Metadata() *FileMetadata {
+	return f.metadata
+}
+
+// Schema returns the root of the schema tree.
+func (f *File) Schema() *SchemaNode {
+	return f.schema
+}
+
+// Leaves returns the primitive columns of the schema in column chunk order.
+func (f *File) Leaves() []*SchemaNode {
+	return f.leaves
+}
+
+// Projection returns the leaves read by Records, RawRecords and Filter: those
+// selected with WithColumns, or all of them.
+func (f *File) Projection() []*SchemaNode {
+	if f.selected == nil {
+		return f.leaves
+	}
+	var out []*SchemaNode
+	for j, leaf := range f.leaves {
+		if f.selected[j] {
+			out = append(out, leaf)
+		}
+	}
+	return out
+}
+
+func (f *File) isSelected(j int) bool {
+	return f.selected == nil || f.selected[j]
+}
+
+// RowGroups returns the row groups described in the footer.
+func (f *File) RowGroups() []RowGroup {
+	return f.metadata.RowGroups
+}
+
+// ColumnChunk returns a reader for column j of row group i.
+func (f *File) ColumnChunk(i, j int) (*ColumnChunkReader, error) {
+	if i < 0 || i >= len(f.metadata.RowGroups) {
+		return nil, fmt.Errorf("row group %d out of range [0, %d)", i, len(f.metadata.RowGroups))
+	}
+	rowGroup := f.metadata.RowGroups[i]
+	if j < 0 || j >= len(rowGroup.Columns) {
+		return nil, fmt.Errorf("column %d out of range [0, %d)", j, len(rowGroup.Columns))
+	}
+
+	if j >= len(f.leaves) {
+		return nil, fmt.Errorf("column %d has no leaf in a schema of %d columns", j, len(f.leaves))
+	}
+
+	return &ColumnChunkReader{r: f.r, chunk: rowGroup.Columns[j], leaf: f.leaves[j], opts: f.opts, numRows: rowGroup.NumRows}, nil
+}
+
+// Records reads the projected columns of row group i and reassembles the rows,
+// keyed by top-level field name; fields without a projected leaf are left
+// out. Groups become map[string]interface{}, lists and bare repeated fields
+// []interface{}, MAP groups map[interface{}]interface{}, and NULLs nil. Primitive values are converted to their logical type as described
+// for ConvertValue; use RawRecords for the physical values.
+func (f *File) Records(i int) ([]map[string]interface{}, error) {
+	return f.records(i, true)
+}
+
+// RawRecords is like Records but keeps primitive values in their physical
+// representation.
+func (f *File) RawRecords(i int) ([]map[string]interface{}, error) {
+	return f.records(i, false)
+}
+
+func (f *File) records(i int, convert bool) ([]map[string]interface{}, error) {
+	if i < 0 || i >= len(f.metadata.RowGroups) {
+		return nil, fmt.Errorf("row group %d out of range [0, %d)", i, len(f.metadata.RowGroups))
+	}
+	rowGroup := f.metadata.RowGroups[i]
+	if len(rowGroup.Columns) != len(f.leaves) {
+		return nil, fmt.Errorf("row group %d has %d column chunks, schema has %d leaves", i, len(rowGroup.Columns), len(f.leaves))
+	}
+
+	columns := make([]*columnData, 0, len(f.leaves))
+	for j, leaf := range f.leaves {
+		if !f.isSelected(j) {
+			continue
+		}
+		data, err := readColumnChunk(f.r, rowGroup.Columns[j], leaf, f.opts)
+		if err != nil {
+			return nil, fmt.Errorf("reading column %s: %w", leaf.DottedPath(), err)
+		}
+		if convert {
+			if data.values, err = convertValues(leaf.Element, data.values); err != nil {
+				return nil, err
+			}
+		}
+		columns = append(columns, data)
+	}
+
+	return assembleRecords(f.schema, columns, int(rowGroup.NumRows))
+}
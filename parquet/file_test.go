@@ -0,0 +1,116 @@
+package parquet
+
+import (
+	"bytes"
+	"reflect"
+	"testing"
+)
+
+func TestSelectColumns(t *testing.T) {
+	_, leaves := testSchema(t)
+
+	tests := []struct {
+		columns []string
+		want    []string
+	}{
+		{nil, nil},
+		{[]string{"id"}, []string{"id"}},
+		{[]string{"name"}, []string{"name.first"}},
+		{[]string{"name.first", "id"}, []string{"id", "name.first"}},
+		{[]string{"tags"}, []string{"tags.list.element"}},
+		{[]string{"tags.list.element"}, []string{"tags.list.element"}},
+		// The keys of a map are read along with its values.
+		{[]string{"attrs.key_value.value"}, []string{"attrs.key_value.key", "attrs.key_value.value"}},
+		{[]string{"attrs.key_value.key"}, []string{"attrs.key_value.key"}},
+		{[]string{"attrs"}, []string{"attrs.key_value.key", "attrs.key_value.value"}},
+	}
+	for _, tt := range tests {
+		selected, err := selectColumns(leaves, tt.columns)
+		if err != nil {
+			t.Errorf("selectColumns(%q): %v", tt.columns, err)
+			continue
+		}
+		var got []string
+		for j, leaf := range leaves {
+			if selected[j] {
+				got = append(got, leaf.DottedPath())
+			}
+		}
+		if !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("selectColumns(%q) selects %q, want %q", tt.columns, got, tt.want)
+		}
+	}
+
+	for _, columns := range [][]string{{"missing"}, {"id.x"}, {"name.last"}, {""}, {"id", "nam"}} {
+		if _, err := selectColumns(leaves, columns); err == nil {
+			t.Errorf("selectColumns(%q) succeeded, want error", columns)
+		}
+	}
+}
+
+func TestRecordsProjection(t *testing.T) {
+	data, spans := writeTestFile(t, nil)
+	r := &recordingReaderAt{r: bytes.NewReader(data)}
+	f, err := OpenFile(r, int64(len(data)), WithColumns("name"))
+	if err != nil {
+		t.Fatalf("OpenFile: %v", err)
+	}
+	if got := f.Projection(); len(got) != 1 || got[0].DottedPath() != "name" {
+		t.Errorf("Projection() = %v, want [name]", got)
+	}
+
+	records, err := f.Records(1)
+	if err != nil {
+		t.Fatalf("Records: %v", err)
+	}
+	if len(records) != testRowsPerGroup {
+		t.Fatalf("got %d records, want %d", len(records), testRowsPerGroup)
+	}
+	for i, record := range records {
+		want := map[string]interface{}{"name": testRecord(testRowsPerGroup + i)["name"]}
+		if !reflect.DeepEqual(record, want) {
+			t.Errorf("record %d = %v, want %v", i, record, want)
+		}
+	}
+
+	// The chunk of "id" is not read.
+	span := spans[1][0]
+	for _, read := range r.reads {
+		if read[0] < span[1] && span[0] < read[1] {
+			t.Errorf("id chunk at [%d, %d) was read at [%d, %d)", span[0], span[1], read[0], read[1])
+		}
+	}
+}
+
+func TestRecordsEmptyProjection(t *testing.T) {
+	// Every page is corrupt, so reading any chunk fails.
+	data, _ := writeTestFile(t, []testPage{{0, -1}, {1, -1}, {2, -1}})
+	f, err := OpenFile(bytes.NewReader(data), int64(len(data)), WithColumns())
+	if err != nil {
+		t.Fatalf("OpenFile: %v", err)
+	}
+	if got := f.Projection(); len(got) != 0 {
+		t.Errorf("Projection() = %v, want none", got)
+	}
+	records, err := f.Records(0)
+	if err != nil {
+		t.Fatalf("Records: %v", err)
+	}
+	if len(records) != testRowsPerGroup {
+		t.Fatalf("got %d records, want %d", len(records), testRowsPerGroup)
+	}
+	for i, record := range records {
+		if len(record) != 0 {
+			t.Errorf("record %d = %v, want empty", i, record)
+		}
+	}
+}
+
+func TestOpenFileUnknownColumn(t *testing.T) {
+	data, _ := writeTestFile(t, nil)
+	for _, column := range []string{"missing", "id.x", "", "nam"} {
+		if _, err := OpenFile(bytes.NewReader(data), int64(len(data)), WithColumns("id", column)); err == nil {
+			t.Errorf("OpenFile with column %q succeeded, want error", column)
+		}
+	}
+}
@@ -0,0 +1,416 @@
+package parquet
+
+import (
+	"fmt"
+	"math"
+	"sort"
+)
+
+// valueRange summarizes the values of a column in a row group or page.
+type valueRange struct {
+	// min and max are logical values, set if hasBounds.
+	min, max  interface{}
+	hasBounds bool
+	// nullCount is -1 if unknown.
+	nullCount int64
+	allNull   bool
+	// bloom is the bloom filter of a column chunk, if loaded.
+	bloom *BloomFilter
+}
+
+// pageRange is the value range of one data page and the rows it holds.
+type pageRange struct {
+	rows   rowRange
+	values *valueRange
+}
+
+// rowRange is the half-open range [start, end) of rows in a row group.
+type rowRange struct {
+	start, end int64
+}
+
+// Filter returns the rows of the file that match p, in file order and in the
+// form returned by Records, projection included; p may test columns outside
+// the projection. Row groups whose statistics or bloom filters rule out a
+// match are skipped; within the others, pages ruled out by the page index are
+// not decoded. The remaining rows are checked one by one.
+func (f *File) Filter(p Predicate) ([]map[string]interface{}, error) {
+	var out []map[string]interface{}
+	for i := range f.metadata.RowGroups {
+		rows, err := f.FilterRowGroup(i, p)
+		if err != nil {
+			return nil, err
+		}
+		out = append(out, rows...)
+	}
+	return out, nil
+}
+
+// FilterRowGroup is like Filter for row group i.
+func (f *File) FilterRowGroup(i int, p Predicate) ([]map[string]interface{}, error) {
+	if i < 0 || i >= len(f.metadata.RowGroups) {
+		return nil, fmt.Errorf("row group %d out of range [0, %d)", i, len(f.metadata.RowGroups))
+	}
+	rowGroup := f.metadata.RowGroups[i]
+	if len(rowGroup.Columns) != len(f.leaves) {
+		return nil, fmt.Errorf("row group %d has %d column chunks, schema has %d leaves", i, len(rowGroup.Columns), len(f.leaves))
+	}
+
+	columns, err := f.predicateColumns(p)
+	if err != nil {
+		return nil, err
+	}
+
+	// Row group pruning with the column chunk statistics.
+	ranges := make(map[string]*valueRange, len(columns))
+	for path, leaf := range columns {
+		ranges[path] = f.chunkRange(rowGroup.Columns[leaf.ColumnIndex], leaf)
+	}
+	if !p.mayMatch(ranges) {
+		return nil, nil
+	}
+
+	// Then with the bloom filters of the columns tested for equality.
+	equality := make(map[string]bool)
+	equalityColumns(p, equality)
+	for path := range equality {
+		leaf := columns[path]
+		col, err := f.ColumnChunk(i, leaf.ColumnIndex)
+		if err != nil {
+			return nil, err
+		}
+		bloom, err := col.BloomFilter()
+		if err != nil {
+			f.opts.warn(fmt.Errorf("column %s: ignoring bloom filter: %v", path, err))
+			continue
+		}
+		if bloom == nil {
+			continue
+		}
+		if ranges[path] == nil {
+			ranges[path] = &valueRange{nullCount: -1}
+		}
+		ranges[path].bloom = bloom
+	}
+	if !p.mayMatch(ranges) {
+		return nil, nil
+	}
+
+	// Page pruning with the page index of the columns that have one.
+	pages := make(map[string][]pageRange, len(columns))
+	for path, leaf := range columns {
+		col, err := f.ColumnChunk(i, leaf.ColumnIndex)
+		if err != nil {
+			return nil, err
+		}
+		if columnPages := f.pageRanges(col, rowGroup.NumRows); columnPages != nil {
+			pages[path] = columnPages
+		}
+	}
+	candidates := p.candidateRows(pages, rowGroup.NumRows)
+	if len(candidates) == 0 {
+		return nil, nil
+	}
+
+	// Decode the candidate rows of the projected and predicate columns.
+	data := make([]*columnData, len(f.leaves))
+	var projected, tested []*columnData
+	for j, leaf := range f.leaves {
+		_, inPredicate := columns[leaf.DottedPath()]
+		if !f.isSelected(j) && !inPredicate {
+			continue
+		}
+		col, err := f.ColumnChunk(i, j)
+		if err != nil {
+			return nil, err
+		}
+		if data[j], err = readRowRanges(col, candidates); err != nil {
+			return nil, fmt.Errorf("reading column %s: %w", leaf.DottedPath(), err)
+		}
+		if data[j].values, err = convertValues(leaf.Element, data[j].values); err != nil {
+			return nil, err
+		}
+		if f.isSelected(j) {
+			projected = append(projected, data[j])
+		}
+		if inPredicate {
+			tested = append(tested, data[j])
+		}
+	}
+
+	// The rows are matched on the predicate columns and returned with the
+	// projected ones, which are the same records without a projection.
+	numRows := int(totalRows(candidates))
+	records, err := assembleRecords(f.schema, projected, numRows)
+	if err != nil {
+		return nil, err
+	}
+	testedRecords := records
+	if f.selected != nil {
+		if testedRecords, err = assembleRecords(f.schema, tested, numRows); err != nil {
+			return nil, err
+		}
+	}
+
+	var out []map[string]interface{}
+	for k, record := range testedRecords {
+		values := make(map[string]interface{}, len(columns))
+		for path, leaf := range columns {
+			values[path] = leafValue(record, leaf)
+		}
+		ok, err := p.match(values)
+		if err != nil {
+			return nil, err
+		}
+		if ok {
+			out = append(out, records[k])
+		}
+	}
+	return out, nil
+}
+
+// predicateColumns resolves the columns referenced by p to their leaves.
+func (f *File) predicateColumns(p Predicate) (map[string]*SchemaNode, error) {
+	byPath := make(map[string]*SchemaNode, len(f.leaves))
+	for _, leaf := range f.leaves {
+		byPath[leaf.DottedPath()] = leaf
+	}
+
+	columns := make(map[string]*SchemaNode)
+	for _, path := range p.columns(nil) {
+		leaf, ok := byPath[path]
+		if !ok {
+			return nil, fmt.Errorf("predicate column %q is not a leaf column", path)
+		}
+		if leaf.MaxRepetitionLevel > 0 {
+			return nil, fmt.Errorf("predicate column %q is repeated", path)
+		}
+		columns[path] = leaf
+	}
+	return columns, nil
+}
+
+// equalityColumns adds the columns that p tests with = or IN to out.
+func equalityColumns(p Predicate, out map[string]bool) {
+	switch p := p.(type) {
+	case *comparison:
+		if p.op == opEq || p.op == opIn {
+			out[p.column] = true
+		}
+	case *junction:
+		for _, child := range p.children {
+			equalityColumns(child, out)
+		}
+	}
+}
+
+// chunkRange summarizes a column chunk from its statistics, or returns nil if
+// it has none.
+func (f *File) chunkRange(chunk ColumnChunk, leaf *SchemaNode) *valueRange {
+	if chunk.MetaData == nil || chunk.MetaData.Statistics == nil {
+		return nil
+	}
+	stats := chunk.MetaData.Statistics
+
+	r := &valueRange{nullCount: -1}
+	if stats.NullCount != nil {
+		r.nullCount = *stats.NullCount
+		r.allNull = r.nullCount == chunk.MetaData.NumValues
+	}
+
+	var order *ColumnOrder
+	if leaf.ColumnIndex < len(f.metadata.ColumnOrders) {
+		order = &f.metadata.ColumnOrders[leaf.ColumnIndex]
+	}
+	min, max, ok, err := stats.Bounds(leaf.Element, order)
+	if err == nil && ok {
+		r.min, r.max, r.hasBounds = logicalBounds(leaf.Element, min, max)
+	}
+	return r
+}
+
+// pageRanges summarizes the data pages of a column chunk from its page index.
+// It returns nil if the chunk has no usable column and offset index; a broken
+// index is reported to the warning handler and ignored.
+func (f *File) pageRanges(col *ColumnChunkReader, numRows int64) []pageRange {
+	if col.chunk.ColumnIndexOffset == nil || col.chunk.OffsetIndexOffset == nil {
+		return nil
+	}
+	columnIndex, err := col.ColumnIndex()
+	if err == nil && columnIndex == nil {
+		return nil
+	}
+	var offsetIndex *OffsetIndex
+	if err == nil {
+		offsetIndex, err = col.OffsetIndex()
+	}
+	if err == nil && (offsetIndex == nil || len(offsetIndex.PageLocations) != columnIndex.NumPages()) {
+		err = fmt.Errorf("offset index does not match the %d pages of the column index", columnIndex.NumPages())
+	}
+	if err != nil {
+		f.opts.warn(fmt.Errorf("column %s: ignoring page index: %v", col.leaf.DottedPath(), err))
+		return nil
+	}
+
+	out := make([]pageRange, columnIndex.NumPages())
+	for i, loc := range offsetIndex.PageLocations {
+		r := &valueRange{nullCount: -1, allNull: columnIndex.NullPages[i]}
+		if i < len(columnIndex.NullCounts) {
+			r.nullCount = columnIndex.NullCounts[i]
+		}
+		min, max, ok, err := columnIndex.Bounds(i, col.leaf.Element)
+		if err == nil && ok {
+			r.min, r.max, r.hasBounds = logicalBounds(col.leaf.Element, min, max)
+		}
+		out[i] = pageRange{rows: rowRange{loc.FirstRowIndex, loc.FirstRowIndex + offsetIndex.NumRows(i, numRows)}, values: r}
+	}
+	return out
+}
+
+// logicalBounds converts physical bounds to the column's logical type, made
+// safe for pruning by normalizeFloatBounds.
+func logicalBounds(elem SchemaElement, min, max interface{}) (interface{}, interface{}, bool) {
+	lo, err := ConvertValue(elem, min)
+	if err != nil {
+		return nil, nil, false
+	}
+	hi, err := ConvertValue(elem, max)
+	if err != nil {
+		return nil, nil, false
+	}
+	return normalizeFloatBounds(lo, hi)
+}
+
+// normalizeFloatBounds handles floating-point bounds: NaN makes them unusable,
+// and a zero bound may have been written with either sign, so it is widened.
+// Other values are returned unchanged.
+func normalizeFloatBounds(min, max interface{}) (interface{}, interface{}, bool) {
+	switch lo := min.(type) {
+	case float32:
+		hi := max.(float32)
+		if lo != lo || hi != hi {
+			return nil, nil, false
+		}
+		if lo == 0 {
+			min = float32(math.Copysign(0, -1))
+		}
+		if hi == 0 {
+			max = float32(0)
+		}
+	case float64:
+		hi := max.(float64)
+		if math.IsNaN(lo) || math.IsNaN(hi) {
+			return nil, nil, false
+		}
+		if lo == 0 {
+			min = math.Copysign(0, -1)
+		}
+		if hi == 0 {
+			max = 0.0
+		}
+	}
+	return min, max, true
+}
+
+// readRowRanges decodes the rows of a column chunk in ranges, seeking past the
+// pages in between.
+func readRowRanges(col *ColumnChunkReader, ranges []rowRange) (*columnData, error) {
+	if len(ranges) == 1 && ranges[0].start == 0 && ranges[0].end >= col.numRows {
+		data, err := readColumnChunk(col.r, col.chunk, col.leaf, col.opts)
+		if err != nil {
+			return nil, err
+		}
+		if rows := data.numRows(); int64(rows) != col.numRows {
+			return nil, fmt.Errorf("column chunk holds %d rows, row group has %d", rows, col.numRows)
+		}
+		return data, nil
+	}
+
+	data := &columnData{leaf: col.leaf}
+	for _, r := range ranges {
+		// Without an offset index a seek rescans the chunk, so skip forward.
+		if cursor := col.cursor; cursor != nil && col.chunk.OffsetIndexOffset == nil && cursor.row <= r.start {
+			skip := int(r.start - cursor.row)
+			if n, err := cursor.read(skip, nil); err != nil {
+				return nil, err
+			} else if n != skip {
+				return nil, fmt.Errorf("skipping to row %d: chunk ended after %d of %d rows", r.start, n, skip)
+			}
+		} else if err := col.SeekToRow(r.start); err != nil {
+			return nil, err
+		}
+		n, err := col.cursor.read(int(r.end-r.start), data)
+		if err != nil {
+			return nil, err
+		}
+		if int64(n) != r.end-r.start {
+			return nil, fmt.Errorf("rows [%d, %d): read %d rows, expected %d", r.start, r.end, n, r.end-r.start)
+		}
+	}
+	return data, nil
+}
+
+// leafValue returns the value of a non-repeated leaf in an assembled record.
+func leafValue(record map[string]interface{}, leaf *SchemaNode) interface{} {
+	var v interface{} = record
+	for _, name := range leaf.Path {
+		group, ok := v.(map[string]interface{})
+		if !ok {
+			return nil
+		}
+		v = group[name]
+	}
+	return v
+}
+
+func totalRows(ranges []rowRange) int64 {
+	var n int64
+	for _, r := range ranges {
+		n += r.end - r.start
+	}
+	return n
+}
+
+// unionRanges merges two sets of row ranges into sorted, disjoint ranges.
+func unionRanges(a, b []rowRange) []rowRange {
+	all := append(append([]rowRange{}, a...), b...)
+	sort.Slice(all, func(i, j int) bool { return all[i].start < all[j].start })
+
+	var out []rowRange
+	for _, r := range all {
+		if r.start >= r.end {
+			continue
+		}
+		if n := len(out); n > 0 && r.start <= out[n-1].end {
+			if r.end > out[n-1].end {
+				out[n-1].end = r.end
+			}
+			continue
+		}
+		out = append(out, r)
+	}
+	return out
+}
+
+// intersectRanges returns the rows in both sets of sorted, disjoint ranges.
+func intersectRanges(a, b []rowRange) []rowRange {
+	var out []rowRange
+	for i, j := 0, 0; i < len(a) && j < len(b); {
+		start, end := a[i].start, a[i].end
+		if b[j].start > start {
+			start = b[j].start
+		}
+		if b[j].end < end {
+			end = b[j].end
+		}
+		if start < end {
+			out = append(out, rowRange{start, end})
+		}
+		if a[i].end < b[j].end {
+			i++
+		} else {
+			j++
+		}
+	}
+	return out
+}
@@ -0,0 +1,429 @@
+package parquet
+
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"reflect"
+	"testing"
+)
+
+// The test file has a required INT64 column "id" and a required STRING column
+// "name", PLAIN encoded and uncompressed, in row groups of equal size with a
+// page index and, for "id", a bloom filter. Row r has id 2r, so odd ids fall
+// within the statistics but are absent.
+const (
+	testRowGroups     = 3
+	testPagesPerGroup = 4
+	testRowsPerPage   = 5
+	testRowsPerGroup  = testPagesPerGroup * testRowsPerPage
+	testRows          = testRowGroups * testRowsPerGroup
+)
+
+func testRecord(row int) map[string]interface{} {
+	return map[string]interface{}{"id": int64(2 * row), "name": fmt.Sprintf("n%03d", 2*row)}
+}
+
+// testPage identifies a data page by row group and page within it; page -1
+// stands for all pages of the row group.
+type testPage struct{ rowGroup, page int }
+
+// writeTestFile returns the test file with the given pages of both columns
+// overwritten, so that decoding them fails, and the byte spans of the data
+// pages of each row group, one per column chunk.
+func writeTestFile(t *testing.T, corrupt []testPage) ([]byte, [][][2]int64) {
+	t.Helper()
+	isCorrupt := func(rowGroup, page int) bool {
+		for _, c := range corrupt {
+			if c.rowGroup == rowGroup && (c.page == page || c.page == -1) {
+				return true
+			}
+		}
+		return false
+	}
+
+	type pageLocation struct{ offset, size, firstRow int64 }
+	type chunk struct {
+		dataOffset, size               int64
+		min, max                       []byte
+		pageMins, pageMaxs             [][]byte
+		locations                      []pageLocation
+		bloomOffset, bloomLength       int64
+		columnIndex, offsetIndex       int64
+		columnIndexLen, offsetIndexLen int64
+	}
+	plain := func(column, row int) []byte {
+		v := testRecord(row)
+		if column == 0 {
+			return binary.LittleEndian.AppendUint64(nil, uint64(v["id"].(int64)))
+		}
+		return []byte(v["name"].(string))
+	}
+
+	out := bytes.NewBufferString("PAR1")
+	chunks := make([][2]chunk, testRowGroups)
+	spans := make([][][2]int64, testRowGroups)
+	for g := range chunks {
+		for c := range chunks[g] {
+			ch := &chunks[g][c]
+			ch.dataOffset = int64(out.Len())
+			var ids []interface{}
+			for p := 0; p < testPagesPerGroup; p++ {
+				first := g*testRowsPerGroup + p*testRowsPerPage
+				var body []byte
+				for row := first; row < first+testRowsPerPage; row++ {
+					if c == 0 {
+						body = append(body, plain(c, row)...)
+						ids = append(ids, int64(2*row))
+					} else {
+						body = binary.LittleEndian.AppendUint32(body, uint32(len(plain(c, row))))
+						body = append(body, plain(c, row)...)
+					}
+				}
+
+				var header compactWriter
+				header.begin()
+				header.i32(1, 0) // DATA_PAGE
+				header.i32(2, int32(len(body)))
+				header.i32(3, int32(len(body)))
+				header.structField(5, func() {
+					header.i32(1, testRowsPerPage)
+					header.i32(2, 0) // PLAIN
+					header.i32(3, 3) // RLE
+					header.i32(4, 3) // RLE
+				})
+				header.end()
+
+				page := append(header.Bytes(), body...)
+				if isCorrupt(g, p) {
+					page = bytes.Repeat([]byte{0xff}, len(page))
+				}
+				ch.locations = append(ch.locations, pageLocation{int64(out.Len()), int64(len(page)), int64(first - g*testRowsPerGroup)})
+				ch.pageMins = append(ch.pageMins, plain(c, first))
+				ch.pageMaxs = append(ch.pageMaxs, plain(c, first+testRowsPerPage-1))
+				out.Write(page)
+			}
+			ch.size = int64(out.Len()) - ch.dataOffset
+			ch.min, ch.max = ch.pageMins[0], ch.pageMaxs[testPagesPerGroup-1]
+			spans[g] = append(spans[g], [2]int64{ch.dataOffset, int64(out.Len())})
+
+			if c == 0 {
+				bloom := newTestBloomFilter(t, SchemaElement{Type: 2}, 256, ids...)
+				var header compactWriter
+				header.begin()
+				header.i32(1, int32(len(bloom.bitset)))
+				header.structField(2, func() { header.structField(1, func() {}) }) // BLOCK
+				header.structField(3, func() { header.structField(1, func() {}) }) // XXHASH
+				header.structField(4, func() { header.structField(1, func() {}) }) // UNCOMPRESSED
+				header.end()
+				ch.bloomOffset = int64(out.Len())
+				out.Write(header.Bytes())
+				out.Write(bloom.bitset)
+				ch.bloomLength = int64(out.Len()) - ch.bloomOffset
+			}
+		}
+	}
+
+	// The page indexes follow the data.
+	for g := range chunks {
+		for c := range chunks[g] {
+			ch := &chunks[g][c]
+			var ci compactWriter
+			ci.begin()
+			ci.list(1, 1, testPagesPerGroup, func() {
+				for range ch.pageMins {
+					ci.WriteByte(2) // false
+				}
+			})
+			ci.list(2, 8, testPagesPerGroup, func() {
+				for _, v := range ch.pageMins {
+					ci.elemBinary(v)
+				}
+			})
+			ci.list(3, 8, testPagesPerGroup, func() {
+				for _, v := range ch.pageMaxs {
+					ci.elemBinary(v)
+				}
+			})
+			ci.i32(4, 1) // ASCENDING
+			ci.list(5, 6, testPagesPerGroup, func() {
+				for range ch.pageMins {
+					ci.varint(0)
+				}
+			})
+			ci.end()
+			ch.columnIndex, ch.columnIndexLen = int64(out.Len()), int64(ci.Len())
+			out.Write(ci.Bytes())
+
+			var oi compactWriter
+			oi.begin()
+			oi.list(1, 12, testPagesPerGroup, func() {
+				for _, loc := range ch.locations {
+					oi.elemStruct(func() {
+						oi.i64(1, loc.offset)
+						oi.i32(2, int32(loc.size))
+						oi.i64(3, loc.firstRow)
+					})
+				}
+			})
+			oi.end()
+			ch.offsetIndex, ch.offsetIndexLen = int64(out.Len()), int64(oi.Len())
+			out.Write(oi.Bytes())
+		}
+	}
+
+	var w compactWriter
+	w.begin()
+	w.i32(1, 2) // version
+	w.list(2, 12, 3, func() {
+		w.elemStruct(func() {
+			w.binary(4, []byte("schema"))
+			w.i32(5, 2)
+		})
+		w.elemStruct(func() {
+			w.i32(1, 2) // INT64
+			w.i32(3, 0) // REQUIRED
+			w.binary(4, []byte("id"))
+		})
+		w.elemStruct(func() {
+			w.i32(1, 6) // BYTE_ARRAY
+			w.i32(3, 0) // REQUIRED
+			w.binary(4, []byte("name"))
+			w.i32(6, 0) // UTF8
+		})
+	})
+	w.i64(3, testRows)
+	w.list(4, 12, testRowGroups, func() {
+		for g := range chunks {
+			w.elemStruct(func() {
+				var total int64
+				w.list(1, 12, 2, func() {
+					for c, ch := range chunks[g] {
+						total += ch.size
+						w.elemStruct(func() {
+							w.i64(2, ch.dataOffset)
+							w.structField(3, func() {
+								w.i32(1, []int32{2, 6}[c])
+								w.list(2, 5, 1, func() { w.varint(0) }) // PLAIN
+								w.list(3, 8, 1, func() { w.elemBinary([]byte([]string{"id", "name"}[c])) })
+								w.i32(4, 0) // UNCOMPRESSED
+								w.i64(5, testRowsPerGroup)
+								w.i64(6, ch.size)
+								w.i64(7, ch.size)
+								w.i64(9, ch.dataOffset)
+								w.structField(12, func() {
+									w.i64(3, 0) // null_count
+									w.binary(5, ch.max)
+									w.binary(6, ch.min)
+								})
+								if ch.bloomLength > 0 {
+									w.i64(14, ch.bloomOffset)
+									w.i32(15, int32(ch.bloomLength))
+								}
+							})
+							w.i64(4, ch.offsetIndex)
+							w.i32(5, int32(ch.offsetIndexLen))
+							w.i64(6, ch.columnIndex)
+							w.i32(7, int32(ch.columnIndexLen))
+						})
+					}
+				})
+				w.i64(2, total)
+				w.i64(3, testRowsPerGroup)
+			})
+		}
+	})
+	w.list(7, 12, 2, func() { // column_orders
+		for range chunks[0] {
+			w.elemStruct(func() { w.structField(1, func() {}) }) // TYPE_ORDER
+		}
+	})
+	w.end()
+
+	out.Write(w.Bytes())
+	out.Write(binary.LittleEndian.AppendUint32(nil, uint32(w.Len())))
+	out.WriteString("PAR1")
+	return out.Bytes(), spans
+}
+
+// recordingReaderAt records the byte ranges read from a file.
+type recordingReaderAt struct {
+	r     *bytes.Reader
+	reads [][2]int64
+}
+
+func (r *recordingReaderAt) ReadAt(p []byte, off int64) (int, error) {
+	n, err := r.r.ReadAt(p, off)
+	r.reads = append(r.reads, [2]int64{off, off + int64(n)})
+	return n, err
+}
+
+func TestFilterPruning(t *testing.T) {
+	tests := []struct {
+		name string
+		p    Predicate
+		// columns is the projection; nil reads all columns.
+		columns []string
+		rows    []int
+		// skipped lists the pages that must not be decoded; they are
+		// overwritten in the file.
+		skipped []testPage
+	}{
+		{
+			name:    "row groups and first page",
+			p:       Gt("id", 95),
+			rows:    rowSequence(48, 60),
+			skipped: []testPage{{0, -1}, {1, -1}, {2, 0}},
+		},
+		{
+			name:    "single page by string",
+			p:       Eq("name", "n050"),
+			rows:    []int{25},
+			skipped: []testPage{{0, -1}, {2, -1}, {1, 0}, {1, 2}, {1, 3}},
+		},
+		{
+			name:    "bloom filter",
+			p:       Eq("id", 51),
+			skipped: []testPage{{0, -1}, {1, -1}, {2, -1}},
+		},
+		{
+			name:    "bloom filter with IN",
+			p:       In("id", 11, 51, 101),
+			skipped: []testPage{{0, -1}, {1, -1}, {2, -1}},
+		},
+		{
+			name:    "pages at both ends",
+			p:       Or(Lt("id", 4), Ge("id", 116)),
+			rows:    []int{0, 1, 58, 59},
+			skipped: []testPage{{1, -1}, {0, 1}, {0, 2}, {0, 3}, {2, 0}, {2, 1}, {2, 2}},
+		},
+		{
+			name:    "across row groups",
+			p:       And(Ge("id", 30), Le("id", 44)),
+			rows:    rowSequence(15, 23),
+			skipped: []testPage{{2, -1}, {0, 0}, {0, 1}, {0, 2}, {1, 1}, {1, 2}, {1, 3}},
+		},
+		{
+			name:    "predicate outside projection",
+			p:       Eq("id", 20),
+			columns: []string{"name"},
+			rows:    []int{10},
+			skipped: []testPage{{1, -1}, {2, -1}, {0, 0}, {0, 1}, {0, 3}},
+		},
+		{
+			name:    "empty projection",
+			p:       Eq("id", 20),
+			columns: []string{},
+			rows:    []int{10},
+			skipped: []testPage{{1, -1}, {2, -1}, {0, 0}, {0, 1}, {0, 3}},
+		},
+		{
+			name:    "no nulls",
+			p:       IsNull("name"),
+			skipped: []testPage{{0, -1}, {1, -1}, {2, -1}},
+		},
+		{
+			name: "no pruning",
+			p:    Ne("name", "x"),
+			rows: rowSequence(0, testRows),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			data, spans := writeTestFile(t, tt.skipped)
+			r := &recordingReaderAt{r: bytes.NewReader(data)}
+			var opts []Option
+			if tt.columns != nil {
+				opts = append(opts, WithColumns(tt.columns...))
+			}
+			f, err := OpenFile(r, int64(len(data)), opts...)
+			if err != nil {
+				t.Fatalf("OpenFile: %v", err)
+			}
+
+			got, err := f.Filter(tt.p)
+			if err != nil {
+				t.Fatalf("Filter(%s): %v", tt.p, err)
+			}
+			var want []map[string]interface{}
+			for _, row := range tt.rows {
+				record := testRecord(row)
+				if tt.columns != nil {
+					projected := map[string]interface{}{}
+					for _, column := range tt.columns {
+						projected[column] = record[column]
+					}
+					record = projected
+				}
+				want = append(want, record)
+			}
+			if !reflect.DeepEqual(got, want) {
+				t.Errorf("Filter(%s) = %v, want %v", tt.p, got, want)
+			}
+
+			// Pruned row groups are not read at all.
+			for _, s := range tt.skipped {
+				if s.page != -1 {
+					continue
+				}
+				for _, span := range spans[s.rowGroup] {
+					for _, read := range r.reads {
+						if read[0] < span[1] && span[0] < read[1] {
+							t.Errorf("pages of row group %d at [%d, %d) were read at [%d, %d)", s.rowGroup, span[0], span[1], read[0], read[1])
+						}
+					}
+				}
+			}
+		})
+	}
+}
+
+func rowSequence(start, end int) []int {
+	var rows []int
+	for row := start; row < end; row++ {
+		rows = append(rows, row)
+	}
+	return rows
+}
+
+func TestRowRanges(t *testing.T) {
+	a := []rowRange{{0, 5}, {10, 15}, {20, 25}}
+	b := []rowRange{{3, 12}, {24, 30}}
+
+	if got, want := unionRanges(a, b), []rowRange{{0, 15}, {20, 30}}; !reflect.DeepEqual(got, want) {
+		t.Errorf("unionRanges = %v, want %v", got, want)
+	}
+	if got, want := unionRanges([]rowRange{{0, 5}, {7, 7}}, []rowRange{{5, 6}}), []rowRange{{0, 6}}; !reflect.DeepEqual(got, want) {
+		t.Errorf("unionRanges with adjacent and empty ranges = %v, want %v", got, want)
+	}
+	if got, want := intersectRanges(a, b), []rowRange{{3, 5}, {10, 12}, {24, 25}}; !reflect.DeepEqual(got, want) {
+		t.Errorf("intersectRanges = %v, want %v", got, want)
+	}
+	if got := intersectRanges(a, nil); got != nil {
+		t.Errorf("intersectRanges with nothing = %v, want nil", got)
+	}
+	if got := totalRows(a); got != 15 {
+		t.Errorf("totalRows = %d, want 15", got)
+	}
+}
+
+func TestReadRowRanges(t *testing.T) {
+	c := seekTestFile(t, seekTestRowGroup{pageRows: []int{4, 4, 2}, withIndex: true})[0]
+	data, err := readRowRanges(c, []rowRange{{1, 3}, {6, 9}})
+	if err != nil {
+		t.Fatalf("readRowRanges: %v", err)
+	}
+	if want := append(rowValues(1, 2), rowValues(6, 3)...); !reflect.DeepEqual(data.values, want) {
+		t.Errorf("got %v, want %v", data.values, want)
+	}
+
+	// A chunk holding fewer rows than its row group is an error, whether it
+	// is read whole or in ranges.
+	short := seekTestFile(t, seekTestRowGroup{pageRows: []int{4, 4, 2}})[0]
+	short.numRows = 12
+	for _, ranges := range [][]rowRange{{{0, 12}}, {{2, 4}, {8, 12}}} {
+		if _, err := readRowRanges(short, ranges); err == nil {
+			t.Errorf("ranges %v: expected an error", ranges)
+		}
+	}
+}
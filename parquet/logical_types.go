@@ -0,0 +1,225 @@
+package parquet
+
+import (
+	"fmt"
+)
+
+// LogicalTypeKind identifies the member of the Thrift LogicalType union that is
+// set. Values are the union's field ids.
+type LogicalTypeKind int32
+
+const (
+	LogicalTypeString    LogicalTypeKind = 1
+	LogicalTypeMap       LogicalTypeKind = 2
+	LogicalTypeList      LogicalTypeKind = 3
+	LogicalTypeEnum      LogicalTypeKind = 4
+	LogicalTypeDecimal   LogicalTypeKind = 5
+	LogicalTypeDate      LogicalTypeKind = 6
+	LogicalTypeTime      LogicalTypeKind = 7
+	LogicalTypeTimestamp LogicalTypeKind = 8
+	LogicalTypeInteger   LogicalTypeKind = 10
+	LogicalTypeUnknown   LogicalTypeKind = 11 // always-null column ("NullType")
+	LogicalTypeJSON      LogicalTypeKind = 12
+	LogicalTypeBSON      LogicalTypeKind = 13
+	LogicalTypeUUID      LogicalTypeKind = 14
+	LogicalTypeFloat16   LogicalTypeKind = 15
+	LogicalTypeVariant   LogicalTypeKind = 16
+	LogicalTypeGeometry  LogicalTypeKind = 17
+	LogicalTypeGeography LogicalTypeKind = 18
+)
+
+func (k LogicalTypeKind) String() string {
+	switch k {
+	case LogicalTypeString:
+		return "STRING"
+	case LogicalTypeMap:
+		return "MAP"
+	case LogicalTypeList:
+		return "LIST"
+	case LogicalTypeEnum:
+		return "ENUM"
+	case LogicalTypeDecimal:
+		return "DECIMAL"
+	case LogicalTypeDate:
+		return "DATE"
+	case LogicalTypeTime:
+		return "TIME"
+	case LogicalTypeTimestamp:
+		return "TIMESTAMP"
+	case LogicalTypeInteger:
+		return "INTEGER"
+	case LogicalTypeUnknown:
+		return "UNKNOWN"
+	case LogicalTypeJSON:
+		return "JSON"
+	case LogicalTypeBSON:
+		return "BSON"
+	case LogicalTypeUUID:
+		return "UUID"
+	case LogicalTypeFloat16:
+		return "FLOAT16"
+	case LogicalTypeVariant:
+		return "VARIANT"
+	case LogicalTypeGeometry:
+		return "GEOMETRY"
+	case LogicalTypeGeography:
+		return "GEOGRAPHY"
+	default:
+		return fmt.Sprintf("LogicalType(%d)", int32(k))
+	}
+}
+
+// LogicalType mirrors the Thrift LogicalType union. Kind says which member is
+// set; the parameter struct of that member, if it has one, is non-nil.
+type LogicalType struct {
+	Kind      LogicalTypeKind
+	Decimal   *DecimalType
+	Time      *TimeType
+	Timestamp *TimestampType
+	Integer   *IntType
+	Variant   *VariantType
+	Geometry  *GeometryType
+	Geography *GeographyType
+}
+
+// TimeUnit mirrors the Thrift TimeUnit union. Values are the union's field ids.
+type TimeUnit int32
+
+const (
+	TimeUnitMillis TimeUnit = 1
+	TimeUnitMicros TimeUnit = 2
+	TimeUnitNanos  TimeUnit = 3
+)
+
+func (u TimeUnit) String() string {
+	switch u {
+	case TimeUnitMillis:
+		return "MILLIS"
+	case TimeUnitMicros:
+		return "MICROS"
+	case TimeUnitNanos:
+		return "NANOS"
+	default:
+		return fmt.Sprintf("TimeUnit(%d)", int32(u))
+	}
+}
+
+type DecimalType struct {
+	Scale     int32
+	Precision int32
+}
+
+type TimeType struct {
+	IsAdjustedToUTC bool
+	Unit            TimeUnit
+}
+
+type TimestampType struct {
+	IsAdjustedToUTC bool
+	Unit            TimeUnit
+}
+
+type IntType struct {
+	BitWidth int8
+	IsSigned bool
+}
+
+type VariantType struct {
+	SpecificationVersion *int8
+}
+
+type GeometryType struct {
+	CRS *string
+}
+
+// GeographyType mirrors the Thrift GeographyType. Algorithm is an
+// EdgeInterpolationAlgorithm (0 = SPHERICAL, 1 = VINCENTY, 2 = THOMAS,
+// 3 = ANDOYER, 4 = KARNEY); SPHERICAL applies when it is absent.
+type GeographyType struct {
+	CRS       *string
+	Algorithm *int32
+}
+
+// String renders the type with its parameters, e.g. "DECIMAL(10,2)" or
+// "TIMESTAMP(MICROS,UTC)".
+func (t *LogicalType) String() string {
+	switch {
+	case t.Decimal != nil:
+		return fmt.Sprintf("%s(%d,%d)", t.Kind, t.Decimal.Precision, t.Decimal.Scale)
+	case t.Time != nil:
+		return fmt.Sprintf("%s(%s,%s)", t.Kind, t.Time.Unit, utcLabel(t.Time.IsAdjustedToUTC))
+	case t.Timestamp != nil:
+		return fmt.Sprintf("%s(%s,%s)", t.Kind, t.Timestamp.Unit, utcLabel(t.Timestamp.IsAdjustedToUTC))
+	case t.Integer != nil:
+		if t.Integer.IsSigned {
+			return fmt.Sprintf("%s(%d,signed)", t.Kind, t.Integer.BitWidth)
+		}
+		return fmt.Sprintf("%s(%d,unsigned)", t.Kind, t.Integer.BitWidth)
+	case t.Geometry != nil && t.Geometry.CRS != nil:
+		return fmt.Sprintf("%s(%s)", t.Kind, *t.Geometry.CRS)
+	case t.Geography != nil && t.Geography.CRS != nil:
+		return fmt.Sprintf("%s(%s)", t.Kind, *t.Geography.CRS)
+	default:
+		return t.Kind.String()
+	}
+}
+
+func utcLabel(adjusted bool) string {
+	if adjusted {
+		return "UTC"
+	}
+	return "local"
+}
+
+// ResolvedLogicalType returns the element's LogicalType, or for files written
+// before LogicalType existed, the equivalent of its ConvertedType. It returns
+// nil when the element has neither, or a ConvertedType without a LogicalType
+// equivalent (MAP_KEY_VALUE, INTERVAL).
+func (e SchemaElement) ResolvedLogicalType() *LogicalType {
+	if e.LogicalType != nil {
+		return e.LogicalType
+	}
+	if e.ConvertedType == nil {
+		return nil
+	}
+
+	switch *e.ConvertedType {
+	case 0: // UTF8
+		return &LogicalType{Kind: LogicalTypeString}
+	case 1: // MAP
+		return &LogicalType{Kind: LogicalTypeMap}
+	case 3: // LIST
+		return &LogicalType{Kind: LogicalTypeList}
+	case 4: // ENUM
+		return &LogicalType{Kind: LogicalTypeEnum}
+	case 5: // DECIMAL
+		dec := &DecimalType{}
+		if e.Scale != nil {
+			dec.Scale = *e.Scale
+		}
+		if e.Precision != nil {
+			dec.Precision = *e.Precision
+		}
+		return &LogicalType{Kind: LogicalTypeDecimal, Decimal: dec}
+	case 6: // DATE
+		return &LogicalType{Kind: LogicalTypeDate}
+	case 7: // TIME_MILLIS
+		return &LogicalType{Kind: LogicalTypeTime, Time: &TimeType{IsAdjustedToUTC: true, Unit: TimeUnitMillis}}
+	case 8: // TIME_MICROS
+		return &LogicalType{Kind: LogicalTypeTime, Time: &TimeType{IsAdjustedToUTC: true, Unit: TimeUnitMicros}}
+	case 9: // TIMESTAMP_MILLIS
+		return &LogicalType{Kind: LogicalTypeTimestamp, Timestamp: &TimestampType{IsAdjustedToUTC: true, Unit: TimeUnitMillis}}
+	case 10: // TIMESTAMP_MICROS
+		return &LogicalType{Kind: LogicalTypeTimestamp, Timestamp: &TimestampType{IsAdjustedToUTC: true, Unit: TimeUnitMicros}}
+	case 11, 12, 13, 14: // UINT_8, UINT_16, UINT_32, UINT_64
+		return &LogicalType{Kind: LogicalTypeInteger, Integer: &IntType{BitWidth: int8(8 << (*e.ConvertedType - 11)), IsSigned: false}}
+	case 15, 16, 17, 18: // INT_8, INT_16, INT_32, INT_64
+		return &LogicalType{Kind: LogicalTypeInteger, Integer: &IntType{BitWidth: int8(8 << (*e.ConvertedType - 15)), IsSigned: true}}
+	case 19: // JSON
+		return &LogicalType{Kind: LogicalTypeJSON}
+	case 20: // BSON
+		return &LogicalType{Kind: LogicalTypeBSON}
+	default: // MAP_KEY_VALUE (2), INTERVAL (21)
+		return nil
+	}
+}
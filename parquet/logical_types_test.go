@@ -0,0 +1,30 @@
+package parquet
+
+import "testing"
+
+func TestResolvedLogicalType(t *testing.T) {
+	tests := []struct {
+		elem SchemaElement
+		want string
+	}{
+		{SchemaElement{ConvertedType: ptr(int32(0))}, "STRING"},
+		{SchemaElement{ConvertedType: ptr(int32(5)), Scale: ptr(int32(3)), Precision: ptr(int32(9))}, "DECIMAL(9,3)"},
+		{SchemaElement{ConvertedType: ptr(int32(10))}, "TIMESTAMP(MICROS,UTC)"},
+		{SchemaElement{ConvertedType: ptr(int32(13))}, "INTEGER(32,unsigned)"},
+		{SchemaElement{ConvertedType: ptr(int32(15))}, "INTEGER(8,signed)"},
+		// LogicalType wins over ConvertedType.
+		{SchemaElement{ConvertedType: ptr(int32(0)), LogicalType: &LogicalType{Kind: LogicalTypeJSON}}, "JSON"},
+		{SchemaElement{ConvertedType: ptr(int32(21))}, ""}, // INTERVAL
+		{SchemaElement{}, ""},
+	}
+	for _, tt := range tests {
+		got := tt.elem.ResolvedLogicalType()
+		var str string
+		if got != nil {
+			str = got.String()
+		}
+		if str != tt.want {
+			t.Errorf("%+v: got %q, want %q", tt.elem, str, tt.want)
+		}
+	}
+}
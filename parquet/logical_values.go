@@ -0,0 +1,374 @@
+package parquet
+
+import (
+	"encoding/binary"
+	"encoding/hex"
+	"fmt"
+	"math"
+	"math/big"
+	"strings"
+	"time"
+)
+
+// Decimal is a DECIMAL value: Unscaled * 10^-Scale.
+type Decimal struct {
+	Unscaled *big.Int
+	Scale    int32
+}
+
+// Rat returns the exact value as a big.Rat.
+func (d Decimal) Rat() *big.Rat {
+	r := new(big.Rat).SetInt(d.Unscaled)
+	if d.Scale > 0 {
+		r.Quo(r, new(big.Rat).SetInt(new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(d.Scale)), nil)))
+	} else if d.Scale < 0 {
+		r.Mul(r, new(big.Rat).SetInt(new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(-d.Scale)), nil)))
+	}
+	return r
+}
+
+// Float64 returns the nearest float64 to the value.
+func (d Decimal) Float64() float64 {
+	f, _ := d.Rat().Float64()
+	return f
+}
+
+func (d Decimal) String() string {
+	if d.Scale <= 0 {
+		return d.Rat().FloatString(0)
+	}
+	digits := new(big.Int).Abs(d.Unscaled).String()
+	if len(digits) <= int(d.Scale) {
+		digits = strings.Repeat("0", int(d.Scale)-len(digits)+1) + digits
+	}
+	point := len(digits) - int(d.Scale)
+	sign := ""
+	if d.Unscaled.Sign() < 0 {
+		sign = "-"
+	}
+	return sign + digits[:point] + "." + digits[point:]
+}
+
+// Date is a DATE value: a calendar day without time zone.
+type Date struct {
+	Year  int
+	Month time.Month
+	Day   int
+}
+
+// DateOf returns the date of a DATE value, counted in days since 1970-01-01.
+func DateOf(daysSinceEpoch int32) Date {
+	t := time.Unix(int64(daysSinceEpoch)*86400, 0).UTC()
+	return Date{Year: t.Year(), Month: t.Month(), Day: t.Day()}
+}
+
+// Time returns midnight UTC of the date.
+func (d Date) Time() time.Time {
+	return time.Date(d.Year, d.Month, d.Day, 0, 0, 0, 0, time.UTC)
+}
+
+func (d Date) String() string {
+	return fmt.Sprintf("%04d-%02d-%02d", d.Year, int(d.Month), d.Day)
+}
+
+// TimeOfDay is a TIME value: the time elapsed since midnight.
+type TimeOfDay time.Duration
+
+func (t TimeOfDay) String() string {
+	d := time.Duration(t)
+	sign := ""
+	if d < 0 {
+		sign = "-"
+		d = -d
+	}
+	s := fmt.Sprintf("%s%02d:%02d:%02d", sign, d/time.Hour, d%time.Hour/time.Minute, d%time.Minute/time.Second)
+	if frac := d % time.Second; frac != 0 {
+		s += strings.TrimRight(fmt.Sprintf(".%09d", int64(frac)), "0")
+	}
+	return s
+}
+
+// UUID is a UUID value in RFC 4122 byte order.
+type UUID [16]byte
+
+func (u UUID) String() string {
+	var buf [36]byte
+	hex.Encode(buf[0:8], u[0:4])
+	buf[8] = '-'
+	hex.Encode(buf[9:13], u[4:6])
+	buf[13] = '-'
+	hex.Encode(buf[14:18], u[6:8])
+	buf[18] = '-'
+	hex.Encode(buf[19:23], u[8:10])
+	buf[23] = '-'
+	hex.Encode(buf[24:], u[10:])
+	return string(buf[:])
+}
+
+// Interval is a legacy INTERVAL value (ConvertedType only): three unsigned
+// little-endian 32-bit counts.
+type Interval struct {
+	Months uint32
+	Days   uint32
+	Millis uint32
+}
+
+func (i Interval) String() string {
+	return fmt.Sprintf("%d months %d days %d ms", i.Months, i.Days, i.Millis)
+}
+
+// ConvertValue converts a raw value as returned by ColumnChunkReader.Values
+// into its logical type, using the element's LogicalType or ConvertedType:
+//
+//   - DECIMAL: Decimal
+//   - DATE: Date
+//   - TIME: TimeOfDay
+//   - TIMESTAMP: time.Time, in UTC; for timestamps not adjusted to UTC the
+//     result holds the local wall-clock reading
+//   - INTEGER: int8, int16, int32, int64, uint8, uint16, uint32 or uint64
+//     by bit width and signedness
+//   - UUID: UUID
+//   - FLOAT16: float32
+//   - INTERVAL: Interval
+//
+// Other values, including nil, are returned unchanged.
+func ConvertValue(elem SchemaElement, v interface{}) (interface{}, error) {
+	convert, err := logicalConverter(elem)
+	if err != nil || convert == nil || v == nil {
+		return v, err
+	}
+	return convert(v)
+}
+
+// convertValues applies ConvertValue to every value.
+func convertValues(elem SchemaElement, values []interface{}) ([]interface{}, error) {
+	convert, err := logicalConverter(elem)
+	if err != nil || convert == nil {
+		return values, err
+	}
+
+	out := make([]interface{}, len(values))
+	for i, v := range values {
+		if v == nil {
+			continue
+		}
+		if out[i], err = convert(v); err != nil {
+			return nil, fmt.Errorf("column %s: %v", elem.Name, err)
+		}
+	}
+	return out, nil
+}
+
+// logicalConverter returns the conversion for values of the element, or nil
+// when its values are used as they are.
+func logicalConverter(elem SchemaElement) (func(interface{}) (interface{}, error), error) {
+	if elem.ConvertedType != nil && *elem.ConvertedType == 21 && elem.LogicalType == nil { // INTERVAL
+		return convertInterval, nil
+	}
+
+	lt := elem.ResolvedLogicalType()
+	if lt == nil {
+		return nil, nil
+	}
+
+	switch lt.Kind {
+	case LogicalTypeDecimal:
+		if lt.Decimal == nil {
+			return nil, fmt.Errorf("column %s: DECIMAL without scale and precision", elem.Name)
+		}
+		scale := lt.Decimal.Scale
+		return func(v interface{}) (interface{}, error) {
+			return convertDecimal(v, scale)
+		}, nil
+	case LogicalTypeDate:
+		return convertDate, nil
+	case LogicalTypeTime:
+		if lt.Time == nil {
+			return nil, fmt.Errorf("column %s: TIME without unit", elem.Name)
+		}
+		unit := lt.Time.Unit
+		return func(v interface{}) (interface{}, error) {
+			return convertTime(v, unit)
+		}, nil
+	case LogicalTypeTimestamp:
+		if lt.Timestamp == nil {
+			return nil, fmt.Errorf("column %s: TIMESTAMP without unit", elem.Name)
+		}
+		unit := lt.Timestamp.Unit
+		return func(v interface{}) (interface{}, error) {
+			return convertTimestamp(v, unit)
+		}, nil
+	case LogicalTypeInteger:
+		if lt.Integer == nil {
+			return nil, fmt.Errorf("column %s: INTEGER without bit width", elem.Name)
+		}
+		bitWidth, signed := lt.Integer.BitWidth, lt.Integer.IsSigned
+		return func(v interface{}) (interface{}, error) {
+			return convertInteger(v, bitWidth, signed)
+		}, nil
+	case LogicalTypeUUID:
+		return convertUUID, nil
+	case LogicalTypeFloat16:
+		return convertFloat16, nil
+	default:
+		return nil, nil
+	}
+}
+
+func convertDecimal(v interface{}, scale int32) (interface{}, error) {
+	switch x := v.(type) {
+	case int32:
+		return Decimal{Unscaled: big.NewInt(int64(x)), Scale: scale}, nil
+	case int64:
+		return Decimal{Unscaled: big.NewInt(x), Scale: scale}, nil
+	case string:
+		// BYTE_ARRAY and FIXED_LEN_BYTE_ARRAY hold big-endian two's complement.
+		unscaled := new(big.Int).SetBytes([]byte(x))
+		if len(x) > 0 && x[0]&0x80 != 0 {
+			unscaled.Sub(unscaled, new(big.Int).Lsh(big.NewInt(1), uint(8*len(x))))
+		}
+		return Decimal{Unscaled: unscaled, Scale: scale}, nil
+	default:
+		return nil, fmt.Errorf("DECIMAL value of type %T", v)
+	}
+}
+
+func convertDate(v interface{}) (interface{}, error) {
+	days, ok := v.(int32)
+	if !ok {
+		return nil, fmt.Errorf("DATE value of type %T", v)
+	}
+	return DateOf(days), nil
+}
+
+func unitDuration(unit TimeUnit) (time.Duration, error) {
+	switch unit {
+	case TimeUnitMillis:
+		return time.Millisecond, nil
+	case TimeUnitMicros:
+		return time.Microsecond, nil
+	case TimeUnitNanos:
+		return time.Nanosecond, nil
+	default:
+		return 0, fmt.Errorf("unknown time unit %d", unit)
+	}
+}
+
+func convertTime(v interface{}, unit TimeUnit) (interface{}, error) {
+	d, err := unitDuration(unit)
+	if err != nil {
+		return nil, err
+	}
+	switch x := v.(type) {
+	case int32: // MILLIS
+		return TimeOfDay(time.Duration(x) * d), nil
+	case int64: // MICROS, NANOS
+		return TimeOfDay(time.Duration(x) * d), nil
+	default:
+		return nil, fmt.Errorf("TIME value of type %T", v)
+	}
+}
+
+func convertTimestamp(v interface{}, unit TimeUnit) (interface{}, error) {
+	x, ok := v.(int64)
+	if !ok {
+		return nil, fmt.Errorf("TIMESTAMP value of type %T", v)
+	}
+	switch unit {
+	case TimeUnitMillis:
+		return time.UnixMilli(x).UTC(), nil
+	case TimeUnitMicros:
+		return time.UnixMicro(x).UTC(), nil
+	case TimeUnitNanos:
+		return time.Unix(0, x).UTC(), nil
+	default:
+		return nil, fmt.Errorf("unknown time unit %d", unit)
+	}
+}
+
+func convertInteger(v interface{}, bitWidth int8, signed bool) (interface{}, error) {
+	var x int64
+	switch raw := v.(type) {
+	case int32:
+		x = int64(raw)
+	case int64:
+		x = raw
+	default:
+		return nil, fmt.Errorf("INTEGER value of type %T", v)
+	}
+
+	switch {
+	case bitWidth == 8 && signed:
+		return int8(x), nil
+	case bitWidth == 16 && signed:
+		return int16(x), nil
+	case bitWidth == 32 && signed:
+		return int32(x), nil
+	case bitWidth == 64 && signed:
+		return x, nil
+	case bitWidth == 8:
+		return uint8(x), nil
+	case bitWidth == 16:
+		return uint16(x), nil
+	case bitWidth == 32:
+		// INT32 storage: reinterpret the 32 bits, not the sign-extended value.
+		return uint32(x), nil
+	case bitWidth == 64:
+		return uint64(x), nil
+	default:
+		return nil, fmt.Errorf("invalid INTEGER bit width %d", bitWidth)
+	}
+}
+
+func convertUUID(v interface{}) (interface{}, error) {
+	s, ok := v.(string)
+	if !ok || len(s) != 16 {
+		return nil, fmt.Errorf("UUID value must be 16 bytes, got %T of length %d", v, len(s))
+	}
+	var u UUID
+	copy(u[:], s)
+	return u, nil
+}
+
+func convertFloat16(v interface{}) (interface{}, error) {
+	s, ok := v.(string)
+	if !ok || len(s) != 2 {
+		return nil, fmt.Errorf("FLOAT16 value must be 2 bytes, got %T of length %d", v, len(s))
+	}
+	return float16ToFloat32(binary.LittleEndian.Uint16([]byte(s))), nil
+}
+
+// float16ToFloat32 widens an IEEE 754 half-precision value.
+func float16ToFloat32(h uint16) float32 {
+	sign := uint32(h>>15) << 31
+	exp := uint32(h>>10) & 0x1f
+	frac := uint32(h) & 0x3ff
+
+	switch {
+	case exp == 0 && frac == 0: // zero
+		return math.Float32frombits(sign)
+	case exp == 0: // subnormal: frac * 2^-24
+		f := float32(frac) / (1 << 24)
+		if sign != 0 {
+			f = -f
+		}
+		return f
+	case exp == 0x1f: // infinity or NaN
+		return math.Float32frombits(sign | 0xff<<23 | frac<<13)
+	default:
+		return math.Float32frombits(sign | (exp+127-15)<<23 | frac<<13)
+	}
+}
+
+func convertInterval(v interface{}) (interface{}, error) {
+	s, ok := v.(string)
+	if !ok || len(s) != 12 {
+		return nil, fmt.Errorf("INTERVAL value must be 12 bytes, got %T of length %d", v, len(s))
+	}
+	b := []byte(s)
+	return Interval{
+		Months: binary.LittleEndian.Uint32(b[0:4]),
+		Days:   binary.LittleEndian.Uint32(b[4:8]),
+		Millis: binary.LittleEndian.Uint32(b[8:12]),
+	}, nil
+}
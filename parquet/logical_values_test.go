@@ -0,0 +1,141 @@
+package parquet
+
+import (
+	"fmt"
+	"math"
+	"math/big"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestConvertValue(t *testing.T) {
+	logical := func(lt LogicalType) SchemaElement { return SchemaElement{LogicalType: &lt} }
+	converted := func(ct int32) SchemaElement { return SchemaElement{ConvertedType: &ct} }
+	decimal := func(scale int32) SchemaElement {
+		return logical(LogicalType{Kind: LogicalTypeDecimal, Decimal: &DecimalType{Scale: scale, Precision: 9}})
+	}
+	timeOf := func(unit TimeUnit) SchemaElement {
+		return logical(LogicalType{Kind: LogicalTypeTime, Time: &TimeType{Unit: unit}})
+	}
+	timestamp := func(unit TimeUnit) SchemaElement {
+		return logical(LogicalType{Kind: LogicalTypeTimestamp, Timestamp: &TimestampType{IsAdjustedToUTC: true, Unit: unit}})
+	}
+	var int96 Int96
+	int96[8] = 1 // Julian day 1
+
+	tests := []struct {
+		name string
+		elem SchemaElement
+		in   interface{}
+		want interface{}
+		str  string // fmt.Sprint of the result
+	}{
+		{"decimal int32", decimal(2), int32(12345), Decimal{big.NewInt(12345), 2}, "123.45"},
+		{"decimal negative int64", decimal(3), int64(-5), Decimal{big.NewInt(-5), 3}, "-0.005"},
+		{"decimal negative fixed", decimal(1), "\xff\x85", Decimal{big.NewInt(-123), 1}, "-12.3"},
+		{"decimal byte array", decimal(0), "\x01\x00", Decimal{big.NewInt(256), 0}, "256"},
+		{"decimal converted type", SchemaElement{ConvertedType: ptr(int32(5)), Scale: ptr(int32(1)), Precision: ptr(int32(2))},
+			int32(7), Decimal{big.NewInt(7), 1}, "0.7"},
+		{"date", logical(LogicalType{Kind: LogicalTypeDate}), int32(18262), Date{2020, time.January, 1}, "2020-01-01"},
+		{"date before epoch", converted(6), int32(-1), Date{1969, time.December, 31}, "1969-12-31"},
+		{"time millis", timeOf(TimeUnitMillis), int32(3723004), TimeOfDay(time.Hour + 2*time.Minute + 3*time.Second + 4*time.Millisecond), "01:02:03.004"},
+		{"time micros", converted(8), int64(1), TimeOfDay(time.Microsecond), "00:00:00.000001"},
+		{"time nanos", timeOf(TimeUnitNanos), int64(86399999999999), TimeOfDay(24*time.Hour - time.Nanosecond), "23:59:59.999999999"},
+		{"timestamp millis", timestamp(TimeUnitMillis), int64(1e12), time.Date(2001, 9, 9, 1, 46, 40, 0, time.UTC), ""},
+		{"timestamp micros before epoch", timestamp(TimeUnitMicros), int64(-1), time.Date(1969, 12, 31, 23, 59, 59, 999999000, time.UTC), ""},
+		{"timestamp nanos before epoch", timestamp(TimeUnitNanos), int64(-1500000000), time.Date(1969, 12, 31, 23, 59, 58, 500000000, time.UTC), ""},
+		{"timestamp converted type", converted(9), int64(-86400000), time.Date(1969, 12, 31, 0, 0, 0, 0, time.UTC), ""},
+		{"int8", converted(15), int32(-128), int8(-128), "-128"},
+		{"int16", logical(LogicalType{Kind: LogicalTypeInteger, Integer: &IntType{BitWidth: 16, IsSigned: true}}), int32(-2), int16(-2), "-2"},
+		{"uint32", converted(13), int32(-1), uint32(math.MaxUint32), "4294967295"},
+		{"uint64", converted(14), int64(-1), uint64(math.MaxUint64), "18446744073709551615"},
+		{"uuid", logical(LogicalType{Kind: LogicalTypeUUID}), "\x00\x11\x22\x33\x44\x55\x66\x77\x88\x99\xaa\xbb\xcc\xdd\xee\xff",
+			UUID{0x00, 0x11, 0x22, 0x33, 0x44, 0x55, 0x66, 0x77, 0x88, 0x99, 0xaa, 0xbb, 0xcc, 0xdd, 0xee, 0xff},
+			"00112233-4455-6677-8899-aabbccddeeff"},
+		{"float16 one", logical(LogicalType{Kind: LogicalTypeFloat16}), "\x00\x3c", float32(1), "1"},
+		{"float16 negative", logical(LogicalType{Kind: LogicalTypeFloat16}), "\x00\xc0", float32(-2), "-2"},
+		{"float16 max", logical(LogicalType{Kind: LogicalTypeFloat16}), "\xff\x7b", float32(65504), "65504"},
+		{"float16 subnormal", logical(LogicalType{Kind: LogicalTypeFloat16}), "\x01\x00", float32(1.0 / (1 << 24)), "5.9604645e-08"},
+		{"float16 infinity", logical(LogicalType{Kind: LogicalTypeFloat16}), "\x00\xfc", float32(math.Inf(-1)), "-Inf"},
+		{"interval", converted(21), "\x01\x00\x00\x00\x02\x00\x00\x00\x03\x00\x00\x00", Interval{1, 2, 3}, "1 months 2 days 3 ms"},
+		{"int96 unchanged", SchemaElement{Type: 3}, int96, int96, ""},
+		{"unannotated", SchemaElement{Type: 1}, int32(5), int32(5), "5"},
+		{"nil", decimal(2), nil, nil, "<nil>"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ConvertValue(tt.elem, tt.in)
+			if err != nil {
+				t.Fatalf("ConvertValue: %v", err)
+			}
+			switch want := tt.want.(type) {
+			case Decimal:
+				d, ok := got.(Decimal)
+				if !ok || d.Unscaled.Cmp(want.Unscaled) != 0 || d.Scale != want.Scale {
+					t.Errorf("got %#v, want %v", got, want)
+				}
+			case time.Time:
+				if ts, ok := got.(time.Time); !ok || !ts.Equal(want) || ts.Location() != time.UTC {
+					t.Errorf("got %v, want %v", got, want)
+				}
+			default:
+				if !reflect.DeepEqual(got, tt.want) {
+					t.Errorf("got %#v, want %#v", got, tt.want)
+				}
+			}
+			if s := fmt.Sprint(got); tt.str != "" && s != tt.str {
+				t.Errorf("printed as %q, want %q", s, tt.str)
+			}
+		})
+	}
+}
+
+func TestConvertValueErrors(t *testing.T) {
+	tests := []struct {
+		name string
+		elem SchemaElement
+		in   interface{}
+	}{
+		{"decimal from double", SchemaElement{LogicalType: &LogicalType{Kind: LogicalTypeDecimal, Decimal: &DecimalType{}}}, 1.5},
+		{"date from int64", SchemaElement{ConvertedType: ptr(int32(6))}, int64(1)},
+		{"timestamp from int32", SchemaElement{ConvertedType: ptr(int32(9))}, int32(1)},
+		{"uuid too short", SchemaElement{LogicalType: &LogicalType{Kind: LogicalTypeUUID}}, "abc"},
+		{"float16 too long", SchemaElement{LogicalType: &LogicalType{Kind: LogicalTypeFloat16}}, "abc"},
+		{"interval too short", SchemaElement{ConvertedType: ptr(int32(21))}, "abc"},
+		{"decimal without parameters", SchemaElement{LogicalType: &LogicalType{Kind: LogicalTypeDecimal}}, int32(1)},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got, err := ConvertValue(tt.elem, tt.in); err == nil {
+				t.Errorf("got %v, expected an error", got)
+			}
+		})
+	}
+}
+
+func TestInt96Time(t *testing.T) {
+	at := func(julianDay uint32, nanos uint64) Int96 {
+		var v Int96
+		for i := 0; i < 8; i++ {
+			v[i] = byte(nanos >> (8 * i))
+		}
+		for i := 0; i < 4; i++ {
+			v[8+i] = byte(julianDay >> (8 * i))
+		}
+		return v
+	}
+	tests := []struct {
+		v    Int96
+		want time.Time
+	}{
+		{at(julianDayOfUnixEpoch, uint64(time.Hour)), time.Date(1970, 1, 1, 1, 0, 0, 0, time.UTC)},
+		{at(julianDayOfUnixEpoch-1, 1), time.Date(1969, 12, 31, 0, 0, 0, 1, time.UTC)},
+		{at(2459216, 0), time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC)},
+	}
+	for _, tt := range tests {
+		if got := tt.v.Time(); !got.Equal(tt.want) {
+			t.Errorf("%x: got %v, want %v", tt.v[:], got, tt.want)
+		}
+	}
+}
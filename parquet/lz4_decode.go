@@ -0,0 +1,83 @@
+package parquet
+
+import (
+	"fmt"
+)
+
+// decodeLZ4Block appends a single raw LZ4 block (no frame header) to dst. The
+// output may not grow dst past its capacity.
+//
+// A block is a sequence of (literals, match) pairs. Each sequence starts with a
+// token whose high nibble is the literal length and low nibble the match
+// length minus 4; a nibble of 15 is extended by following bytes until one is
+// not 255. The match is a 2-byte little-endian offset back into the output.
+// The last sequence has literals only.
+func decodeLZ4Block(dst, src []byte) ([]byte, error) {
+	maxSize := cap(dst)
+	i := 0
+
+	readLength := func(n int) (int, error) {
+		if n != 15 {
+			return n, nil
+		}
+		for {
+			if i >= len(src) {
+				return 0, fmt.Errorf("lz4: truncated length at offset %d", i)
+			}
+			b := src[i]
+			i++
+			n += int(b)
+			if b != 255 {
+				return n, nil
+			}
+		}
+	}
+
+	for i < len(src) {
+		token := src[i]
+		i++
+
+		literals, err := readLength(int(token >> 4))
+		if err != nil {
+			return nil, err
+		}
+		if literals > len(src)-i {
+			return nil, fmt.Errorf("lz4: %d literals at offset %d overrun the block", literals, i)
+		}
+		if literals > maxSize-len(dst) {
+			return nil, fmt.Errorf("lz4: output exceeds %d bytes", maxSize)
+		}
+		dst = append(dst, src[i:i+literals]...)
+		i += literals
+
+		if i == len(src) {
+			break // last sequence
+		}
+
+		if i+2 > len(src) {
+			return nil, fmt.Errorf("lz4: truncated match offset at offset %d", i)
+		}
+		offset := int(src[i]) | int(src[i+1])<<8
+		i += 2
+		if offset == 0 || offset > len(dst) {
+			return nil, fmt.Errorf("lz4: invalid match offset %d with %d bytes of output", offset, len(dst))
+		}
+
+		matchLength, err := readLength(int(token & 0x0f))
+		if err != nil {
+			return nil, err
+		}
+		matchLength += 4
+		if matchLength > maxSize-len(dst) {
+			return nil, fmt.Errorf("lz4: output exceeds %d bytes", maxSize)
+		}
+
+		// The match may overlap the bytes it produces, so copy byte by byte.
+		start := len(dst) - offset
+		for k := 0; k < matchLength; k++ {
+			dst = append(dst, dst[start+k])
+		}
+	}
+
+	return dst, nil
+}
@@ -0,0 +1,87 @@
+package parquet
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+func TestDecodeLZ4Block(t *testing.T) {
+	tests := []struct {
+		name string
+		src  []byte
+		want string
+	}{
+		{"literals only", []byte{0x50, 'h', 'e', 'l', 'l', 'o'}, "hello"},
+		{"empty", []byte{0x00}, ""},
+		// A match at offset 1 repeats the last byte (run-length encoding).
+		{"overlap offset 1", []byte{0x15, 'a', 0x01, 0x00, 0x00}, "aaaaaaaaaa"},
+		{"overlap offset 2", []byte{0x22, 'a', 'b', 0x02, 0x00, 0x00}, "abababab"},
+		{"match then literals", []byte{0x40, 'a', 'b', 'c', 'd', 0x04, 0x00, 0x10, 'e'}, "abcdabcde"},
+		{"extended literal length", append([]byte{0xf0, 0x05}, "abcdefghijklmnopqrst"...), "abcdefghijklmnopqrst"},
+		{"extended literal length 255", append([]byte{0xf0, 0xff, 0x00}, strings.Repeat("z", 270)...), strings.Repeat("z", 270)},
+		// 15 + 10 + 4 = 29 bytes of match after one literal.
+		{"extended match length", []byte{0x1f, 'x', 0x01, 0x00, 0x0a}, strings.Repeat("x", 30)},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := decodeLZ4Block(make([]byte, 0, len(tt.want)), tt.src)
+			if err != nil {
+				t.Fatalf("decodeLZ4Block: %v", err)
+			}
+			if string(got) != tt.want {
+				t.Errorf("got %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestDecodeLZ4BlockErrors(t *testing.T) {
+	tests := []struct {
+		name    string
+		src     []byte
+		maxSize int
+	}{
+		{"truncated literal length", []byte{0xf0, 0xff}, 300},
+		{"truncated match length", []byte{0x1f, 'x', 0x01, 0x00}, 30},
+		{"literals overrun block", []byte{0x50, 'h', 'i'}, 5},
+		{"truncated match offset", []byte{0x10, 'a', 0x01}, 10},
+		{"zero offset", []byte{0x10, 'a', 0x00, 0x00}, 10},
+		{"offset before output", []byte{0x10, 'a', 0x02, 0x00}, 10},
+		{"literals exceed output", []byte{0x50, 'h', 'e', 'l', 'l', 'o'}, 4},
+		{"match exceeds output", []byte{0x15, 'a', 0x01, 0x00}, 9},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got, err := decodeLZ4Block(make([]byte, 0, tt.maxSize), tt.src); err == nil {
+				t.Fatalf("got %q, expected an error", got)
+			}
+		})
+	}
+}
+
+func TestDecompressLZ4Hadoop(t *testing.T) {
+	block := []byte{0x15, 'a', 0x01, 0x00, 0x00}
+	framed := append([]byte{0, 0, 0, 10, 0, 0, 0, byte(len(block))}, block...)
+	framed = append(framed, append([]byte{0, 0, 0, 5, 0, 0, 0, 6}, 0x50, 'h', 'e', 'l', 'l', 'o')...)
+
+	tests := []struct {
+		name string
+		src  []byte
+		want string
+	}{
+		{"hadoop frames", framed, "aaaaaaaaaahello"},
+		{"raw block", block, "aaaaaaaaaa"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := decompressLZ4Hadoop(make([]byte, 0, len(tt.want)), tt.src)
+			if err != nil {
+				t.Fatalf("decompressLZ4Hadoop: %v", err)
+			}
+			if !bytes.Equal(got, []byte(tt.want)) {
+				t.Errorf("got %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
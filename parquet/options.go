@@ -0,0 +1,57 @@
+package parquet
+
+import (
+	"log"
+)
+
+// Option configures a File opened with OpenFile.
+type Option func(*options)
+
+type options struct {
+	checksum ChecksumMode
+	warn     func(error)
+	// columns is the projection set by WithColumns; project is set even if
+	// it names no column.
+	columns []string
+	project bool
+}
+
+func defaultOptions() options {
+	return options{
+		checksum: ChecksumOff,
+		warn: func(err error) {
+			log.Printf("parquet: %v", err)
+		},
+	}
+}
+
+// WithChecksumMode selects how page CRCs are verified while reading data.
+// The default is ChecksumOff.
+func WithChecksumMode(mode ChecksumMode) Option {
+	return func(o *options) {
+		o.checksum = mode
+	}
+}
+
+// WithWarningHandler sets the function that receives non-fatal problems, such
+// as CRC mismatches in ChecksumWarn mode. By default they are written to the
+// standard logger.
+func WithWarningHandler(fn func(error)) Option {
+	return func(o *options) {
+		if fn != nil {
+			o.warn = fn
+		}
+	}
+}
+
+// WithColumns restricts Records, RawRecords and Filter to the given columns,
+// each named by the dotted path of a leaf or of a group, which selects every
+// leaf below it. The keys of a selected MAP are always read. The chunks of
+// other columns are not. OpenFile fails if a name matches no column. With no
+// names no chunk is read, and Records returns one empty record per row.
+func WithColumns(columns ...string) Option {
+	return func(o *options) {
+		o.columns = append(o.columns, columns...)
+		o.project = true
+	}
+}
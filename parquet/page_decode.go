@@ -0,0 +1,166 @@
+package parquet
+
+import (
+	"fmt"
+	"math/bits"
+)
+
+// parseDataPageWithEncoding decodes a DATA_PAGE (V1). It returns the dense
+// non-null values and the page's repetition and definition levels; a nil level
+// slice means the page carries no levels of that kind.
+func parseDataPageWithEncoding(data []byte, header *DataPageHeader, dataType int32, typeLength int, maxRepetitionLevel byte, maxDefinitionLevel byte, dictionary []interface{}) ([]interface{}, []uint32, []uint32, error) {
+	pageData := data
+	numValues := int(header.NumValues)
+	var repetitionLevels []uint32
+	// definitionLevels stays nil when the page carries no definition levels.
+	var definitionLevels []uint32
+
+	// A level section is absent when its max level is 0.
+	if maxRepetitionLevel > 0 {
+		levels, remaining, err := decodeLevelsV1(pageData, header.RepetitionLevelEncoding, numValues, maxRepetitionLevel)
+		if err != nil {
+			return nil, nil, nil, fmt.Errorf("decoding repetition levels: %v", err)
+		}
+		pageData = remaining
+		repetitionLevels = levels
+	}
+
+	if maxDefinitionLevel > 0 {
+		levels, remaining, err := decodeLevelsV1(pageData, header.DefinitionLevelEncoding, numValues, maxDefinitionLevel)
+		if err != nil {
+			return nil, nil, nil, fmt.Errorf("decoding definition levels: %v", err)
+		}
+		pageData = remaining
+		definitionLevels = levels
+	}
+
+	// Only non-null values are stored in the page.
+	numNonNull := numValues
+	if definitionLevels != nil {
+		numNonNull = 0
+		for _, level := range definitionLevels {
+			if level == uint32(maxDefinitionLevel) {
+				numNonNull++
+			}
+		}
+	}
+
+	values, err := decodeValues(pageData, dataType, typeLength, int64(header.Encoding), numNonNull, dictionary)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	return values, repetitionLevels, definitionLevels, nil
+}
+
+// decodeLevelsV1 decodes one level section of a V1 data page at the bit width
+// needed for maxLevel and returns the bytes after it.
+func decodeLevelsV1(data []byte, encoding int32, numValues int, maxLevel byte) ([]uint32, []byte, error) {
+	bitWidth := uint(bits.Len8(maxLevel))
+
+	var levels []uint32
+	var remaining []byte
+	var err error
+	switch encoding {
+	case 3: // RLE, behind a 4-byte length prefix
+		levels, remaining, err = decodeRLELevels(data, numValues, bitWidth)
+	case 4: // BIT_PACKED (deprecated)
+		levels, remaining, err = decodeBitPackedLevels(data, numValues, bitWidth)
+	default:
+		return nil, nil, fmt.Errorf("unsupported level encoding: %d", encoding)
+	}
+	if err != nil {
+		return nil, nil, err
+	}
+	if err := checkLevels(levels, numValues, maxLevel); err != nil {
+		return nil, nil, err
+	}
+	return levels, remaining, nil
+}
+
+// parseDataPageV2 decodes a DATA_PAGE_V2. Repetition and definition levels are
+// stored uncompressed in front of the values, without the 4-byte length prefix
+// used by V1 pages; only the values section may be compressed, and is
+// decompressed into dst when it can hold it. The results are the same as for
+// parseDataPageWithEncoding.
+func parseDataPageV2(data, dst []byte, header *DataPageHeaderV2, codec int32, uncompressedSize int, dataType int32, typeLength int, maxRepetitionLevel byte, maxDefinitionLevel byte, dictionary []interface{}) ([]interface{}, []uint32, []uint32, error) {
+	repLength := int(header.RepetitionLevelsByteLength)
+	defLength := int(header.DefinitionLevelsByteLength)
+	if repLength < 0 || defLength < 0 || repLength+defLength > len(data) {
+		return nil, nil, nil, fmt.Errorf("invalid level lengths in data page v2: repetition=%d definition=%d page=%d", repLength, defLength, len(data))
+	}
+
+	numValues := int(header.NumValues)
+	var repetitionLevels, definitionLevels []uint32
+	if maxRepetitionLevel > 0 {
+		var err error
+		repetitionLevels, _, err = decodeRLEHybrid(data[:repLength], numValues, uint(bits.Len8(maxRepetitionLevel)))
+		if err != nil {
+			return nil, nil, nil, fmt.Errorf("decoding repetition levels: %v", err)
+		}
+		if err := checkLevels(repetitionLevels, numValues, maxRepetitionLevel); err != nil {
+			return nil, nil, nil, fmt.Errorf("decoding repetition levels: %v", err)
+		}
+	}
+	if maxDefinitionLevel > 0 {
+		var err error
+		definitionLevels, _, err = decodeRLEHybrid(data[repLength:repLength+defLength], numValues, uint(bits.Len8(maxDefinitionLevel)))
+		if err != nil {
+			return nil, nil, nil, fmt.Errorf("decoding definition levels: %v", err)
+		}
+		if err := checkLevels(definitionLevels, numValues, maxDefinitionLevel); err != nil {
+			return nil, nil, nil, fmt.Errorf("decoding definition levels: %v", err)
+		}
+	}
+
+	valuesData := data[repLength+defLength:]
+	if header.IsCompressed {
+		var err error
+		valuesData, err = decompressData(dst, valuesData, codec, uncompressedSize-repLength-defLength)
+		if err != nil {
+			return nil, nil, nil, fmt.Errorf("decompressing data page v2: %v", err)
+		}
+	}
+
+	values, err := decodeValues(valuesData, dataType, typeLength, int64(header.Encoding), numValues-int(header.NumNulls), dictionary)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	return values, repetitionLevels, definitionLevels, nil
+}
+
+// checkLevels verifies that exactly numValues levels were decoded and that none
+// exceeds maxLevel.
+func checkLevels(levels []uint32, numValues int, maxLevel byte) error {
+	if len(levels) != numValues {
+		return fmt.Errorf("got %d levels, expected %d", len(levels), numValues)
+	}
+	for i, level := range levels {
+		if level > uint32(maxLevel) {
+			return fmt.Errorf("level %d at index %d exceeds maximum %d", level, i, maxLevel)
+		}
+	}
+	return nil
+}
+
+// decodeValues decodes numValues non-null values in the given encoding.
+// typeLength is the schema's type_length, used by FIXED_LEN_BYTE_ARRAY columns.
+func decodeValues(data []byte, dataType int32, typeLength int, encoding int64, numValues int, dictionary []interface{}) ([]interface{}, error) {
+	switch encoding {
+	case 0: // PLAIN
+		return decodePlainValues(data, dataType, typeLength, numValues)
+	case 2, 8: // PLAIN_DICTIONARY, RLE_DICTIONARY
+		return decodeDictionaryIndices(data, numValues, dictionary)
+	case 3: // RLE (BOOLEAN values only)
+		return decodeRLEBooleanValues(data, dataType, numValues)
+	case 5: // DELTA_BINARY_PACKED
+		return decodeDeltaBinaryPacked(data, dataType, numValues)
+	case 6: // DELTA_LENGTH_BYTE_ARRAY
+		return decodeDeltaLengthByteArray(data, dataType, numValues)
+	case 7: // DELTA_BYTE_ARRAY
+		return decodeDeltaByteArray(data, dataType, numValues)
+	case 9: // BYTE_STREAM_SPLIT
+		return decodeByteStreamSplit(data, dataType, typeLength, numValues)
+	default:
+		return nil, fmt.Errorf("unsupported encoding %d for type %d", encoding, dataType)
+	}
+}
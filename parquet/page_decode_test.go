@@ -0,0 +1,156 @@
+package parquet
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/klauspost/compress/snappy"
+)
+
+func TestParseDataPageV2(t *testing.T) {
+	// An optional INT32 column in a repeated context: repetition levels
+	// 0 0 0 0 as an RLE run, definition levels 1 0 1 1 bit-packed, and the
+	// three non-null values.
+	repLevels := []byte{0x08, 0x00}
+	defLevels := []byte{0x03, 0x0d}
+	values := []byte{1, 0, 0, 0, 2, 0, 0, 0, 3, 0, 0, 0}
+	want := []interface{}{int32(1), int32(2), int32(3)}
+
+	page := func(values []byte) []byte {
+		return append(append(append([]byte{}, repLevels...), defLevels...), values...)
+	}
+	header := func(isCompressed bool) *DataPageHeaderV2 {
+		return &DataPageHeaderV2{
+			NumValues: 4, NumNulls: 1, NumRows: 4, Encoding: 0,
+			DefinitionLevelsByteLength: int32(len(defLevels)),
+			RepetitionLevelsByteLength: int32(len(repLevels)),
+			IsCompressed:               isCompressed,
+		}
+	}
+	uncompressedSize := len(page(values))
+
+	tests := []struct {
+		name   string
+		data   []byte
+		header *DataPageHeaderV2
+	}{
+		// Only the values are compressed; the levels stay as they are.
+		{"compressed", page(snappy.Encode(nil, values)), header(true)},
+		{"not compressed", page(values), header(false)},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, repetitionLevels, definitionLevels, err := parseDataPageV2(tt.data, nil, tt.header, 1, uncompressedSize, 1, 0, 1, 1, nil)
+			if err != nil {
+				t.Fatalf("parseDataPageV2: %v", err)
+			}
+			if !reflect.DeepEqual(got, want) {
+				t.Errorf("values: got %v, want %v", got, want)
+			}
+			if want := []uint32{0, 0, 0, 0}; !reflect.DeepEqual(repetitionLevels, want) {
+				t.Errorf("repetition levels: got %v, want %v", repetitionLevels, want)
+			}
+			if want := []uint32{1, 0, 1, 1}; !reflect.DeepEqual(definitionLevels, want) {
+				t.Errorf("definition levels: got %v, want %v", definitionLevels, want)
+			}
+		})
+	}
+
+	bad := header(false)
+	bad.DefinitionLevelsByteLength = int32(len(page(values)))
+	if _, _, _, err := parseDataPageV2(page(values), nil, bad, 1, uncompressedSize, 1, 0, 1, 1, nil); err == nil {
+		t.Error("level lengths past the page: expected an error")
+	}
+	if _, _, _, err := parseDataPageV2(page(values), nil, header(true), 1, uncompressedSize, 1, 0, 1, 1, nil); err == nil {
+		t.Error("uncompressed values flagged as compressed: expected an error")
+	}
+}
+
+func TestParseDataPageLevels(t *testing.T) {
+	// A repeated, nullable INT32 column with 4 slots: repetition levels
+	// 0 1 0 0 and definition levels 1 0 1 1, each one bit-packed group of
+	// width 1 behind a 4-byte length.
+	data := []byte{
+		0x02, 0x00, 0x00, 0x00, 0x03, 0x02, // repetition levels
+		0x02, 0x00, 0x00, 0x00, 0x03, 0x0d, // definition levels
+		1, 0, 0, 0, 2, 0, 0, 0, 3, 0, 0, 0,
+	}
+	header := &DataPageHeader{NumValues: 4, Encoding: 0, DefinitionLevelEncoding: 3, RepetitionLevelEncoding: 3}
+	values, repetitionLevels, definitionLevels, err := parseDataPageWithEncoding(data, header, 1, 0, 1, 1, nil)
+	if err != nil {
+		t.Fatalf("parseDataPageWithEncoding: %v", err)
+	}
+	if want := []interface{}{int32(1), int32(2), int32(3)}; !reflect.DeepEqual(values, want) {
+		t.Errorf("values: got %v, want %v", values, want)
+	}
+	if want := []uint32{0, 1, 0, 0}; !reflect.DeepEqual(repetitionLevels, want) {
+		t.Errorf("repetition levels: got %v, want %v", repetitionLevels, want)
+	}
+	if want := []uint32{1, 0, 1, 1}; !reflect.DeepEqual(definitionLevels, want) {
+		t.Errorf("definition levels: got %v, want %v", definitionLevels, want)
+	}
+
+	tests := []struct {
+		name               string
+		data               []byte
+		maxDefinitionLevel byte
+	}{
+		// Max level 2 needs 2 bits, which can hold a 3.
+		{"level above maximum", []byte{0x02, 0x00, 0x00, 0x00, 0x08, 0x03}, 2},
+		{"too few levels", []byte{0x02, 0x00, 0x00, 0x00, 0x04, 0x01}, 1},
+		{"length past page", []byte{0x09, 0x00, 0x00, 0x00, 0x08, 0x01}, 1},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, _, _, err := parseDataPageWithEncoding(tt.data, header, 1, 0, 0, tt.maxDefinitionLevel, nil); err == nil {
+				t.Error("expected an error")
+			}
+		})
+	}
+}
+
+func TestDecodeLevelsV1(t *testing.T) {
+	// Levels 1 0 2 3 at max level 3 (bit width 2), followed by a value byte.
+	want := []uint32{1, 0, 2, 3}
+	tests := []struct {
+		name     string
+		encoding int32
+		data     []byte
+	}{
+		// One bit-packed group of 8 hybrid values, LSB first, behind a length.
+		{"RLE", 3, []byte{0x03, 0x00, 0x00, 0x00, 0x03, 0xe1, 0x00, 0xaa}},
+		// Packed from the most significant bit, with no length.
+		{"BIT_PACKED", 4, []byte{0x4b, 0xaa}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, remaining, err := decodeLevelsV1(tt.data, tt.encoding, len(want), 3)
+			if err != nil {
+				t.Fatalf("decodeLevelsV1: %v", err)
+			}
+			if !reflect.DeepEqual(got, want) {
+				t.Errorf("got %v, want %v", got, want)
+			}
+			if !reflect.DeepEqual(remaining, []byte{0xaa}) {
+				t.Errorf("remaining: got %x, want aa", remaining)
+			}
+		})
+	}
+
+	errorTests := []struct {
+		name     string
+		encoding int32
+		data     []byte
+	}{
+		{"PLAIN levels", 0, []byte{0x4b}},
+		{"BIT_PACKED above maximum", 4, []byte{0xff}},
+		{"BIT_PACKED truncated", 4, nil},
+	}
+	for _, tt := range errorTests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, _, err := decodeLevelsV1(tt.data, tt.encoding, len(want), 2); err == nil {
+				t.Error("expected an error")
+			}
+		})
+	}
+}
@@ -0,0 +1,103 @@
+package parquet
+
+import (
+	"fmt"
+	"io"
+
+	"kaitai_parquet/kaitai_gen"
+)
+
+// ColumnIndex reads the column index of the chunk from the page index, or
+// returns nil if the chunk has none.
+func (c *ColumnChunkReader) ColumnIndex() (*ColumnIndex, error) {
+	if c.chunk.ColumnIndexOffset == nil || c.chunk.ColumnIndexLength == nil {
+		return nil, nil
+	}
+	st, err := readPageIndexStruct(c.r, *c.chunk.ColumnIndexOffset, *c.chunk.ColumnIndexLength)
+	if err != nil {
+		return nil, fmt.Errorf("reading column index: %v", err)
+	}
+	index, err := decodeColumnIndex(st)
+	if err != nil {
+		return nil, fmt.Errorf("decoding column index: %v", err)
+	}
+	if n := len(index.NullPages); len(index.MinValues) != n || len(index.MaxValues) != n {
+		return nil, fmt.Errorf("column index has %d null_pages, %d min_values and %d max_values", n, len(index.MinValues), len(index.MaxValues))
+	}
+	return index, nil
+}
+
+// OffsetIndex reads the offset index of the chunk from the page index, or
+// returns nil if the chunk has none. The index is read once and cached.
+func (c *ColumnChunkReader) OffsetIndex() (*OffsetIndex, error) {
+	if c.chunk.OffsetIndexOffset == nil || c.chunk.OffsetIndexLength == nil {
+		return nil, nil
+	}
+	if c.offsetIndex != nil {
+		return c.offsetIndex, nil
+	}
+	st, err := readPageIndexStruct(c.r, *c.chunk.OffsetIndexOffset, *c.chunk.OffsetIndexLength)
+	if err != nil {
+		return nil, fmt.Errorf("reading offset index: %v", err)
+	}
+	index, err := decodeOffsetIndex(st)
+	if err != nil {
+		return nil, fmt.Errorf("decoding offset index: %v", err)
+	}
+	c.offsetIndex = index
+	return index, nil
+}
+
+// readPageIndexStruct reads length bytes at offset and parses them with the
+// Kaitai-generated Thrift Compact parser.
+func readPageIndexStruct(r io.ReaderAt, offset int64, length int32) (*kaitai_gen.ThriftCompact_CompactStruct, error) {
+	if offset < 0 || length <= 0 {
+		return nil, fmt.Errorf("invalid location %d+%d", offset, length)
+	}
+	buf := make([]byte, length)
+	if _, err := r.ReadAt(buf, offset); err != nil {
+		return nil, err
+	}
+	st, consumed, err := parseCompactStructFromBytes(buf)
+	if err != nil {
+		return nil, err
+	}
+	if consumed != len(buf) {
+		return nil, fmt.Errorf("struct ends after %d of %d bytes", consumed, len(buf))
+	}
+	return st, nil
+}
+
+// NumPages returns the number of data pages described by the index.
+func (ci *ColumnIndex) NumPages() int {
+	return len(ci.NullPages)
+}
+
+// Bounds returns the lower and upper bound of data page i for a column with
+// the given schema element, decoded like Statistics.Bounds. ok is false for
+// pages containing only nulls and for columns without a known sort order.
+func (ci *ColumnIndex) Bounds(i int, elem SchemaElement) (min, max interface{}, ok bool, err error) {
+	if i < 0 || i >= ci.NumPages() {
+		return nil, nil, false, fmt.Errorf("page %d out of range [0, %d)", i, ci.NumPages())
+	}
+	if ci.NullPages[i] || ColumnSortOrder(elem) == SortOrderUnknown {
+		return nil, nil, false, nil
+	}
+
+	if min, err = decodeStatValue(ci.MinValues[i], elem); err != nil {
+		return nil, nil, false, fmt.Errorf("decoding min of page %d: %v", i, err)
+	}
+	if max, err = decodeStatValue(ci.MaxValues[i], elem); err != nil {
+		return nil, nil, false, fmt.Errorf("decoding max of page %d: %v", i, err)
+	}
+	return min, max, true, nil
+}
+
+// NumRows returns the number of rows in page i of a row group with numRows
+// rows.
+func (oi *OffsetIndex) NumRows(i int, numRows int64) int64 {
+	if i+1 < len(oi.PageLocations) {
+		return oi.PageLocations[i+1].FirstRowIndex - oi.PageLocations[i].FirstRowIndex
+	}
+	return numRows - oi.PageLocations[i].FirstRowIndex
+}
@@ -0,0 +1,204 @@
+package parquet
+
+import (
+	"bytes"
+	"reflect"
+	"testing"
+)
+
+// writeColumnIndex writes a ColumnIndex for int32 pages; nil bounds mark
+// null pages.
+func writeColumnIndex(w *compactWriter, mins, maxs []*int32) {
+	plain := func(v *int32) []byte {
+		if v == nil {
+			return nil
+		}
+		return []byte{byte(*v), byte(*v >> 8), byte(*v >> 16), byte(*v >> 24)}
+	}
+	w.begin()
+	w.list(1, 1, len(mins), func() { // null_pages: list<bool>
+		for i, v := range mins {
+			switch {
+			case v == nil:
+				w.WriteByte(1)
+			case i%2 == 0:
+				w.WriteByte(2) // false, as written by most writers
+			default:
+				w.WriteByte(0) // false, as written by some writers
+			}
+		}
+	})
+	w.list(2, 8, len(mins), func() { // min_values
+		for _, v := range mins {
+			w.elemBinary(plain(v))
+		}
+	})
+	w.list(3, 8, len(maxs), func() { // max_values
+		for _, v := range maxs {
+			w.elemBinary(plain(v))
+		}
+	})
+	w.i32(4, 1) // boundary_order: ASCENDING
+	// null_counts
+	w.list(5, 6, len(mins), func() {
+		for _, v := range mins {
+			if v == nil {
+				w.varint(10)
+			} else {
+				w.varint(0)
+			}
+		}
+	})
+	w.end()
+}
+
+// pageIndexReader returns a reader for a chunk whose column and offset index
+// are stored after some unrelated leading bytes.
+func pageIndexReader(columnIndex, offsetIndex []byte) *ColumnChunkReader {
+	file := append([]byte("PAR1"), columnIndex...)
+	file = append(file, offsetIndex...)
+	chunk := ColumnChunk{
+		ColumnIndexOffset: ptr(int64(4)),
+		ColumnIndexLength: ptr(int32(len(columnIndex))),
+		OffsetIndexOffset: ptr(int64(4 + len(columnIndex))),
+		OffsetIndexLength: ptr(int32(len(offsetIndex))),
+	}
+	return &ColumnChunkReader{r: bytes.NewReader(file), chunk: chunk, opts: defaultOptions()}
+}
+
+func TestColumnIndex(t *testing.T) {
+	var ci compactWriter
+	writeColumnIndex(&ci, []*int32{ptr(int32(-5)), nil, ptr(int32(7))}, []*int32{ptr(int32(3)), nil, ptr(int32(20))})
+	var oi compactWriter
+	oi.begin()
+	oi.list(1, 12, 3, func() { // page_locations
+		for i, first := range []int64{0, 100, 250} {
+			oi.elemStruct(func() {
+				oi.i64(1, 1000+int64(i)*64) // offset
+				oi.i32(2, 64)               // compressed_page_size
+				oi.i64(3, first)            // first_row_index
+			})
+		}
+	})
+	oi.list(2, 6, 3, func() { oi.varint(1); oi.varint(2); oi.varint(3) }) // unencoded_byte_array_data_bytes
+	oi.end()
+
+	c := pageIndexReader(ci.Bytes(), oi.Bytes())
+	index, err := c.ColumnIndex()
+	if err != nil {
+		t.Fatalf("ColumnIndex: %v", err)
+	}
+	want := &ColumnIndex{
+		NullPages:     []bool{false, true, false},
+		MinValues:     [][]byte{{0xfb, 0xff, 0xff, 0xff}, {}, {7, 0, 0, 0}},
+		MaxValues:     [][]byte{{3, 0, 0, 0}, {}, {20, 0, 0, 0}},
+		BoundaryOrder: 1,
+		NullCounts:    []int64{0, 10, 0},
+	}
+	if !reflect.DeepEqual(index, want) {
+		t.Errorf("got %+v, want %+v", index, want)
+	}
+
+	elem := SchemaElement{Type: 1}
+	if min, max, ok, err := index.Bounds(0, elem); err != nil || !ok || min != int32(-5) || max != int32(3) {
+		t.Errorf("page 0: got %v, %v, %v, %v", min, max, ok, err)
+	}
+	if _, _, ok, err := index.Bounds(1, elem); err != nil || ok {
+		t.Errorf("null page: got ok %v, err %v", ok, err)
+	}
+	if _, _, ok, err := index.Bounds(2, SchemaElement{Type: 3}); err != nil || ok {
+		t.Errorf("INT96 page: got ok %v, err %v", ok, err)
+	}
+	if _, _, _, err := index.Bounds(3, elem); err == nil {
+		t.Error("page 3: expected an error")
+	}
+
+	offsets, err := c.OffsetIndex()
+	if err != nil {
+		t.Fatalf("OffsetIndex: %v", err)
+	}
+	wantOffsets := &OffsetIndex{
+		PageLocations: []PageLocation{
+			{Offset: 1000, CompressedPageSize: 64, FirstRowIndex: 0},
+			{Offset: 1064, CompressedPageSize: 64, FirstRowIndex: 100},
+			{Offset: 1128, CompressedPageSize: 64, FirstRowIndex: 250},
+		},
+		UnencodedByteArrayDataBytes: []int64{1, 2, 3},
+	}
+	if !reflect.DeepEqual(offsets, wantOffsets) {
+		t.Errorf("got %+v, want %+v", offsets, wantOffsets)
+	}
+	for i, want := range []int64{100, 150, 50} {
+		if got := offsets.NumRows(i, 300); got != want {
+			t.Errorf("NumRows(%d) = %d, want %d", i, got, want)
+		}
+	}
+}
+
+func TestColumnIndexBoolList(t *testing.T) {
+	// 20 pages need the extended list size; every third one is a null page.
+	n := 20
+	mins := make([]*int32, n)
+	wantNulls := make([]bool, n)
+	for i := range mins {
+		if i%3 == 0 {
+			wantNulls[i] = true
+		} else {
+			mins[i] = ptr(int32(i))
+		}
+	}
+	var w compactWriter
+	writeColumnIndex(&w, mins, mins)
+
+	index, err := pageIndexReader(w.Bytes(), nil).ColumnIndex()
+	if err != nil {
+		t.Fatalf("ColumnIndex: %v", err)
+	}
+	if !reflect.DeepEqual(index.NullPages, wantNulls) {
+		t.Errorf("null_pages: got %v, want %v", index.NullPages, wantNulls)
+	}
+	// The fields after the list only line up if each bool took one byte.
+	if index.BoundaryOrder != 1 || len(index.MinValues) != n || len(index.NullCounts) != n {
+		t.Errorf("fields after null_pages: got %+v", index)
+	}
+}
+
+func TestPageIndexMissing(t *testing.T) {
+	c := &ColumnChunkReader{r: bytes.NewReader(nil)}
+	if index, err := c.ColumnIndex(); index != nil || err != nil {
+		t.Errorf("ColumnIndex: got %v, %v", index, err)
+	}
+	if index, err := c.OffsetIndex(); index != nil || err != nil {
+		t.Errorf("OffsetIndex: got %v, %v", index, err)
+	}
+}
+
+func TestColumnIndexErrors(t *testing.T) {
+	var valid compactWriter
+	writeColumnIndex(&valid, []*int32{ptr(int32(1))}, []*int32{ptr(int32(2))})
+
+	var mismatched compactWriter
+	writeColumnIndex(&mismatched, []*int32{ptr(int32(1)), ptr(int32(2))}, []*int32{ptr(int32(2))})
+
+	tests := []struct {
+		name string
+		c    *ColumnChunkReader
+	}{
+		{"mismatched lengths", pageIndexReader(mismatched.Bytes(), nil)},
+		{"trailing bytes", pageIndexReader(append(valid.Bytes(), 0), nil)},
+		{"truncated", pageIndexReader(valid.Bytes()[:valid.Len()-2], nil)},
+		{"beyond the file", &ColumnChunkReader{r: bytes.NewReader(valid.Bytes()), chunk: ColumnChunk{
+			ColumnIndexOffset: ptr(int64(4)), ColumnIndexLength: ptr(int32(valid.Len())),
+		}}},
+		{"negative offset", &ColumnChunkReader{r: bytes.NewReader(valid.Bytes()), chunk: ColumnChunk{
+			ColumnIndexOffset: ptr(int64(-1)), ColumnIndexLength: ptr(int32(valid.Len())),
+		}}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if index, err := tt.c.ColumnIndex(); err == nil {
+				t.Errorf("got %+v, expected an error", index)
+			}
+		})
+	}
+}
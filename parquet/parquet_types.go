@@ -0,0 +1,359 @@
+package parquet
+
+import (
+	"encoding/binary"
+	"time"
+)
+
+// FileMetadata is a simplified in-memory representation of Parquet FileMetaData.
+// It is populated by decoding the Thrift Compact-encoded footer.
+type FileMetadata struct {
+	Version                  int32
+	Schema                   []SchemaElement
+	NumRows                  int64
+	RowGroups                []RowGroup
+	KeyValueMetadata         []KeyValue
+	CreatedBy                *string
+	ColumnOrders             []ColumnOrder
+	EncryptionAlgorithm      *EncryptionAlgorithm
+	FooterSigningKeyMetadata []byte
+}
+
+// KeyValue looks up a key in the file's key/value metadata. A key that is
+// present without a value yields "" and true.
+func (m *FileMetadata) KeyValue(key string) (string, bool) {
+	for _, kv := range m.KeyValueMetadata {
+		if kv.Key == key {
+			if kv.Value == nil {
+				return "", true
+			}
+			return *kv.Value, true
+		}
+	}
+	return "", false
+}
+
+// ColumnOrder mirrors the Thrift ColumnOrder union, one per leaf column.
+// TYPE_ORDER is the only member defined so far; an unknown member leaves
+// TypeDefinedOrder false, in which case min/max statistics must be ignored.
+type ColumnOrder struct {
+	TypeDefinedOrder bool
+}
+
+// EncryptionAlgorithm mirrors the Thrift EncryptionAlgorithm union; one of the
+// members is set.
+type EncryptionAlgorithm struct {
+	AesGcmV1    *AesGcm
+	AesGcmCtrV1 *AesGcm
+}
+
+// AesGcm holds the parameters shared by the Thrift AesGcmV1 and AesGcmCtrV1
+// structs.
+type AesGcm struct {
+	AADPrefix       []byte
+	AADFileUnique   []byte
+	SupplyAADPrefix *bool
+}
+
+type SchemaElement struct {
+	Type           int32
+	TypeLength     *int32
+	RepetitionType *int32
+	Name           string
+	NumChildren    *int32
+	ConvertedType  *int32
+	Scale          *int32
+	Precision      *int32
+	FieldID        *int32
+	LogicalType    *LogicalType
+}
+
+type RowGroup struct {
+	Columns             []ColumnChunk
+	TotalByteSize       int64
+	NumRows             int64
+	SortingColumns      []SortingColumn
+	FileOffset          int64
+	TotalCompressedSize int64
+	Ordinal             int32
+}
+
+type ColumnChunk struct {
+	FilePath          []string
+	FileOffset        int64
+	MetaData          *ColumnMetaData
+	OffsetIndexOffset *int64
+	OffsetIndexLength *int32
+	ColumnIndexOffset *int64
+	ColumnIndexLength *int32
+}
+
+type ColumnMetaData struct {
+	Type                  int32
+	Encodings             []int32
+	PathInSchema          []string
+	Codec                 int32
+	NumValues             int64
+	TotalUncompressedSize int64
+	TotalCompressedSize   int64
+	KeyValueMeta          []KeyValue
+	DataPageOffset        int64
+	IndexPageOffset       *int64
+	DictionaryPageOffset  *int64
+	Statistics            *Statistics
+	EncodingStats         []PageEncodingStats
+	BloomFilterOffset     *int64
+	BloomFilterLength     *int32
+	SizeStatistics        *SizeStatistics
+	GeospatialStatistics  *GeospatialStatistics
+}
+
+type KeyValue struct {
+	Key   string
+	Value *string
+}
+
+type Statistics struct {
+	Max             []byte
+	Min             []byte
+	NullCount       *int64
+	DistinctCount   *int64
+	MaxValue        []byte
+	MinValue        []byte
+	IsMaxValueExact *bool
+	IsMinValueExact *bool
+}
+
+type PageEncodingStats struct {
+	PageType int32
+	Encoding int32
+	Count    int32
+}
+
+type SizeStatistics struct {
+	UnencodedByteArrayDataBytes *int64
+	RepetitionLevelHistogram    []int64
+	DefinitionLevelHistogram    []int64
+}
+
+// GeospatialStatistics mirrors the Thrift GeospatialStatistics of GEOMETRY and
+// GEOGRAPHY columns. GeospatialTypes holds WKB type codes.
+type GeospatialStatistics struct {
+	BBox            *BoundingBox
+	GeospatialTypes []int32
+}
+
+type BoundingBox struct {
+	XMin, XMax float64
+	YMin, YMax float64
+	ZMin, ZMax *float64
+	MMin, MMax *float64
+}
+
+// ColumnIndex mirrors the Thrift ColumnIndex of the page index: one entry per
+// data page in each list. Min and max values are encoded like statistics
+// min_value/max_value and are unset for null pages.
+type ColumnIndex struct {
+	NullPages                 []bool
+	MinValues                 [][]byte
+	MaxValues                 [][]byte
+	BoundaryOrder             int32
+	NullCounts                []int64
+	RepetitionLevelHistograms []int64
+	DefinitionLevelHistograms []int64
+}
+
+// OffsetIndex mirrors the Thrift OffsetIndex of the page index.
+type OffsetIndex struct {
+	PageLocations               []PageLocation
+	UnencodedByteArrayDataBytes []int64
+}
+
+type PageLocation struct {
+	Offset             int64
+	CompressedPageSize int32
+	FirstRowIndex      int64
+}
+
+// BloomFilterHeader mirrors the Thrift BloomFilterHeader that precedes the
+// bitset of a bloom filter. Each union records whether its only defined member
+// is set.
+type BloomFilterHeader struct {
+	NumBytes    int32
+	Algorithm   BloomFilterAlgorithm
+	Hash        BloomFilterHash
+	Compression BloomFilterCompression
+}
+
+type BloomFilterAlgorithm struct {
+	Block bool // split block algorithm
+}
+
+type BloomFilterHash struct {
+	XxHash bool
+}
+
+type BloomFilterCompression struct {
+	Uncompressed bool
+}
+
+// PageHeader mirrors the Thrift PageHeader. Only the page-specific header
+// matching Type is set.
+type PageHeader struct {
+	Type                 int32
+	UncompressedPageSize int32
+	CompressedPageSize   int32
+	CRC                  *int32
+	DataPageHeader       *DataPageHeader
+	IndexPageHeader      *IndexPageHeader
+	DictionaryPageHeader *DictionaryPageHeader
+	DataPageHeaderV2     *DataPageHeaderV2
+}
+
+// NumValues returns the value count of the page-specific header, 0 if there is
+// none.
+func (h *PageHeader) NumValues() int32 {
+	switch {
+	case h.DataPageHeader != nil:
+		return h.DataPageHeader.NumValues
+	case h.DictionaryPageHeader != nil:
+		return h.DictionaryPageHeader.NumValues
+	case h.DataPageHeaderV2 != nil:
+		return h.DataPageHeaderV2.NumValues
+	}
+	return 0
+}
+
+// Encoding returns the value encoding of the page-specific header, -1 if there
+// is none.
+func (h *PageHeader) Encoding() int32 {
+	switch {
+	case h.DataPageHeader != nil:
+		return h.DataPageHeader.Encoding
+	case h.DictionaryPageHeader != nil:
+		return h.DictionaryPageHeader.Encoding
+	case h.DataPageHeaderV2 != nil:
+		return h.DataPageHeaderV2.Encoding
+	}
+	return -1
+}
+
+// DataPageHeader mirrors the Thrift DataPageHeader of a DATA_PAGE (V1).
+type DataPageHeader struct {
+	NumValues               int32
+	Encoding                int32
+	DefinitionLevelEncoding int32
+	RepetitionLevelEncoding int32
+	Statistics              *Statistics
+}
+
+// IndexPageHeader mirrors the Thrift IndexPageHeader, which has no fields yet.
+type IndexPageHeader struct{}
+
+// DictionaryPageHeader mirrors the Thrift DictionaryPageHeader.
+type DictionaryPageHeader struct {
+	NumValues int32
+	Encoding  int32
+	IsSorted  *bool
+}
+
+// DataPageHeaderV2 mirrors the Thrift DataPageHeaderV2. Levels of a V2 page are
+// never compressed; IsCompressed only applies to the values section.
+type DataPageHeaderV2 struct {
+	NumValues                  int32
+	NumNulls                   int32
+	NumRows                    int32
+	Encoding                   int32
+	DefinitionLevelsByteLength int32
+	RepetitionLevelsByteLength int32
+	IsCompressed               bool
+	Statistics                 *Statistics
+}
+
+type SortingColumn struct {
+	ColumnIdx  int32
+	Descending bool
+	NullsFirst bool
+}
+
+// Int96 is a legacy INT96 timestamp as written by Impala and Hive: 8 bytes of
+// nanoseconds within the day followed by 4 bytes of Julian day number, both
+// little-endian.
+type Int96 [12]byte
+
+// julianDayOfUnixEpoch is the Julian day number of 1970-01-01.
+const julianDayOfUnixEpoch = 2440588
+
+// NanosOfDay returns the nanoseconds-within-day part.
+func (v Int96) NanosOfDay() int64 {
+	return int64(binary.LittleEndian.Uint64(v[0:8]))
+}
+
+// JulianDay returns the Julian day number part.
+func (v Int96) JulianDay() int32 {
+	return int32(binary.LittleEndian.Uint32(v[8:12]))
+}
+
+// Time converts the value to a UTC time.Time.
+func (v Int96) Time() time.Time {
+	days := int64(v.JulianDay()) - julianDayOfUnixEpoch
+	return time.Unix(days*86400, v.NanosOfDay()).UTC()
+}
+
+func (v Int96) String() string {
+	return v.Time().Format(time.RFC3339Nano)
+}
+
+// TypeName returns a human-readable name for a Parquet physical type.
+func TypeName(typeID int32) string {
+	switch typeID {
+	case 0:
+		return "BOOLEAN"
+	case 1:
+		return "INT32"
+	case 2:
+		return "INT64"
+	case 3:
+		return "INT96"
+	case 4:
+		return "FLOAT"
+	case 5:
+		return "DOUBLE"
+	case 6:
+		return "BYTE_ARRAY"
+	case 7:
+		return "FIXED_LEN_BYTE_ARRAY"
+	default:
+		return "UNKNOWN"
+	}
+}
+
+// PageTypeName returns a human-readable name for a Parquet page type.
+func PageTypeName(pageType int32) string {
+	switch pageType {
+	case 0:
+		return "DATA_PAGE"
+	case 1:
+		return "INDEX_PAGE"
+	case 2:
+		return "DICTIONARY_PAGE"
+	case 3:
+		return "DATA_PAGE_V2"
+	default:
+		return "UNKNOWN"
+	}
+}
+
+// BoundaryOrderName returns a human-readable name for a ColumnIndex boundary order.
+func BoundaryOrderName(order int32) string {
+	switch order {
+	case 0:
+		return "UNORDERED"
+	case 1:
+		return "ASCENDING"
+	case 2:
+		return "DESCENDING"
+	default:
+		return "UNKNOWN"
+	}
+}
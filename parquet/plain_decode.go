@@ -0,0 +1,94 @@
+package parquet
+
+import (
+	"encoding/binary"
+	"fmt"
+	"io"
+	"math"
+)
+
+// decodePlainValues decodes PLAIN-encoded values. typeLength is only used for
+// FIXED_LEN_BYTE_ARRAY columns. It fails with io.ErrUnexpectedEOF if data ends
+// before numValues values.
+func decodePlainValues(data []byte, dataType int32, typeLength int, numValues int) ([]interface{}, error) {
+	values := make([]interface{}, 0, numValues)
+	offset := 0
+
+	// truncated reports a value that does not fit in the rest of data.
+	truncated := func(i, need int) error {
+		return fmt.Errorf("PLAIN %s value %d of %d needs %d bytes, %d left: %w",
+			TypeName(dataType), i, numValues, need, len(data)-offset, io.ErrUnexpectedEOF)
+	}
+
+	if dataType == 0 { // BOOLEAN: bit-packed, LSB first
+		if (numValues+7)/8 > len(data) {
+			return nil, fmt.Errorf("PLAIN BOOLEAN: %d values need %d bytes, have %d: %w",
+				numValues, (numValues+7)/8, len(data), io.ErrUnexpectedEOF)
+		}
+		for i := 0; i < numValues; i++ {
+			values = append(values, data[i/8]&(1<<(i%8)) != 0)
+		}
+		return values, nil
+	}
+	if dataType == 7 && typeLength <= 0 {
+		return values, fmt.Errorf("invalid type length %d for FIXED_LEN_BYTE_ARRAY", typeLength)
+	}
+
+	for i := 0; i < numValues; i++ {
+		switch dataType {
+		case 1: // INT32
+			if offset+4 > len(data) {
+				return nil, truncated(i, 4)
+			}
+			values = append(values, int32(binary.LittleEndian.Uint32(data[offset:])))
+			offset += 4
+		case 2: // INT64
+			if offset+8 > len(data) {
+				return nil, truncated(i, 8)
+			}
+			values = append(values, int64(binary.LittleEndian.Uint64(data[offset:])))
+			offset += 8
+		case 3: // INT96
+			if offset+12 > len(data) {
+				return nil, truncated(i, 12)
+			}
+			var val Int96
+			copy(val[:], data[offset:offset+12])
+			values = append(values, val)
+			offset += 12
+		case 4: // FLOAT
+			if offset+4 > len(data) {
+				return nil, truncated(i, 4)
+			}
+			values = append(values, math.Float32frombits(binary.LittleEndian.Uint32(data[offset:])))
+			offset += 4
+		case 5: // DOUBLE
+			if offset+8 > len(data) {
+				return nil, truncated(i, 8)
+			}
+			values = append(values, math.Float64frombits(binary.LittleEndian.Uint64(data[offset:])))
+			offset += 8
+		case 6: // BYTE_ARRAY
+			if offset+4 > len(data) {
+				return nil, truncated(i, 4)
+			}
+			length := int(binary.LittleEndian.Uint32(data[offset:]))
+			offset += 4
+			if length > len(data)-offset {
+				return nil, truncated(i, length)
+			}
+			values = append(values, string(data[offset:offset+length]))
+			offset += length
+		case 7: // FIXED_LEN_BYTE_ARRAY
+			if offset+typeLength > len(data) {
+				return nil, truncated(i, typeLength)
+			}
+			values = append(values, string(data[offset:offset+typeLength]))
+			offset += typeLength
+		default:
+			return values, fmt.Errorf("unsupported data type: %d", dataType)
+		}
+	}
+
+	return values, nil
+}
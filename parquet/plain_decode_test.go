@@ -0,0 +1,68 @@
+package parquet
+
+import (
+	"errors"
+	"io"
+	"reflect"
+	"testing"
+)
+
+func TestDecodePlainValues(t *testing.T) {
+	tests := []struct {
+		name       string
+		data       []byte
+		dataType   int32
+		typeLength int
+		numValues  int
+		want       []interface{}
+	}{
+		{"boolean", []byte{0x05}, 0, 0, 3, []interface{}{true, false, true}},
+		{"int32", []byte{1, 0, 0, 0, 0xff, 0xff, 0xff, 0xff}, 1, 0, 2, []interface{}{int32(1), int32(-1)}},
+		{"int64", []byte{2, 0, 0, 0, 0, 0, 0, 0}, 2, 0, 1, []interface{}{int64(2)}},
+		{"int96", make([]byte, 12), 3, 0, 1, []interface{}{Int96{}}},
+		{"float", []byte{0, 0, 0x80, 0x3f}, 4, 0, 1, []interface{}{float32(1)}},
+		{"double", []byte{0, 0, 0, 0, 0, 0, 0xf0, 0x3f}, 5, 0, 1, []interface{}{float64(1)}},
+		{"byte array", []byte{2, 0, 0, 0, 'h', 'i', 0, 0, 0, 0}, 6, 0, 2, []interface{}{"hi", ""}},
+		{"fixed len byte array", []byte("abcd"), 7, 2, 2, []interface{}{"ab", "cd"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := decodePlainValues(tt.data, tt.dataType, tt.typeLength, tt.numValues)
+			if err != nil {
+				t.Fatalf("decodePlainValues: %v", err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("got %#v, want %#v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestDecodePlainValuesTruncated(t *testing.T) {
+	tests := []struct {
+		name       string
+		data       []byte
+		dataType   int32
+		typeLength int
+		numValues  int
+	}{
+		{"boolean", []byte{0xff}, 0, 0, 9},
+		{"int32", []byte{1, 0, 0}, 1, 0, 1},
+		{"int64", make([]byte, 12), 2, 0, 2},
+		{"int96", make([]byte, 20), 3, 0, 2},
+		{"float", nil, 4, 0, 1},
+		{"double", make([]byte, 7), 5, 0, 1},
+		{"byte array missing value", []byte{1, 0, 0, 0, 'a'}, 6, 0, 2},
+		{"byte array short value", []byte{5, 0, 0, 0, 'a'}, 6, 0, 1},
+		{"byte array short length", []byte{1, 0}, 6, 0, 1},
+		{"fixed len byte array", []byte("abc"), 7, 2, 2},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := decodePlainValues(tt.data, tt.dataType, tt.typeLength, tt.numValues)
+			if !errors.Is(err, io.ErrUnexpectedEOF) {
+				t.Fatalf("got %v, %v; want io.ErrUnexpectedEOF", got, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,512 @@
+package parquet
+
+import (
+	"bytes"
+	"fmt"
+	"math"
+	"math/big"
+	"reflect"
+	"strings"
+	"time"
+)
+
+// Predicate is a filter over the rows of a file, built with Eq, Lt, In, And,
+// Not and the other constructors below and applied by File.Filter. Columns are
+// named by the dotted path of a leaf that is not repeated, and values are
+// compared with the column's logical values as returned by Records: strings
+// for STRING, Date for DATE, time.Time for timestamps, Decimal or any Go
+// number for DECIMAL, and so on. Numbers of different Go types compare by
+// value.
+//
+// NULLs follow SQL: a comparison with NULL does not match, whether or not it
+// is negated; use IsNull and IsNotNull to test for them.
+type Predicate interface {
+	fmt.Stringer
+
+	negate() Predicate
+	columns(out []string) []string
+	// match reports whether a row, given as column path to logical value,
+	// satisfies the predicate.
+	match(row map[string]interface{}) (bool, error)
+	// mayMatch reports whether any row summarized by ranges may satisfy the
+	// predicate. Columns missing from ranges are unknown.
+	mayMatch(ranges map[string]*valueRange) bool
+	// candidateRows returns the rows of a row group that may satisfy the
+	// predicate, given the page ranges of the columns with a page index.
+	candidateRows(pages map[string][]pageRange, numRows int64) []rowRange
+}
+
+type compareOp int
+
+const (
+	opEq compareOp = iota
+	opNe
+	opLt
+	opLe
+	opGt
+	opGe
+	opIn
+	opNotIn
+	opIsNull
+	opIsNotNull
+)
+
+var compareOpNames = map[compareOp]string{
+	opEq: "=", opNe: "!=", opLt: "<", opLe: "<=", opGt: ">", opGe: ">=",
+	opIn: "IN", opNotIn: "NOT IN", opIsNull: "IS NULL", opIsNotNull: "IS NOT NULL",
+}
+
+// comparison tests a single column. values holds one operand, several for
+// IN and NOT IN, and none for the NULL tests.
+type comparison struct {
+	column string
+	op     compareOp
+	values []interface{}
+}
+
+// Eq matches rows where column equals value.
+func Eq(column string, value interface{}) Predicate {
+	return &comparison{column: column, op: opEq, values: []interface{}{value}}
+}
+
+// Ne matches rows where column is not NULL and differs from value.
+func Ne(column string, value interface{}) Predicate {
+	return &comparison{column: column, op: opNe, values: []interface{}{value}}
+}
+
+// Lt matches rows where column is less than value.
+func Lt(column string, value interface{}) Predicate {
+	return &comparison{column: column, op: opLt, values: []interface{}{value}}
+}
+
+// Le matches rows where column is less than or equal to value.
+func Le(column string, value interface{}) Predicate {
+	return &comparison{column: column, op: opLe, values: []interface{}{value}}
+}
+
+// Gt matches rows where column is greater than value.
+func Gt(column string, value interface{}) Predicate {
+	return &comparison{column: column, op: opGt, values: []interface{}{value}}
+}
+
+// Ge matches rows where column is greater than or equal to value.
+func Ge(column string, value interface{}) Predicate {
+	return &comparison{column: column, op: opGe, values: []interface{}{value}}
+}
+
+// In matches rows where column equals one of values.
+func In(column string, values ...interface{}) Predicate {
+	return &comparison{column: column, op: opIn, values: values}
+}
+
+// IsNull matches rows where column is NULL.
+func IsNull(column string) Predicate {
+	return &comparison{column: column, op: opIsNull}
+}
+
+// IsNotNull matches rows where column is not NULL.
+func IsNotNull(column string) Predicate {
+	return &comparison{column: column, op: opIsNotNull}
+}
+
+// And matches rows satisfying every predicate; with none it matches all rows.
+func And(predicates ...Predicate) Predicate {
+	return &junction{and: true, children: predicates}
+}
+
+// Or matches rows satisfying at least one predicate; with none it matches no
+// rows.
+func Or(predicates ...Predicate) Predicate {
+	return &junction{children: predicates}
+}
+
+// Not matches rows not satisfying p. It is pushed down to the comparisons,
+// so that, as in SQL, Not(Lt(c, v)) behaves like Ge(c, v) and neither
+// matches NULLs.
+func Not(p Predicate) Predicate {
+	return p.negate()
+}
+
+func (c *comparison) String() string {
+	name := compareOpNames[c.op]
+	switch c.op {
+	case opIsNull, opIsNotNull:
+		return fmt.Sprintf("%s %s", c.column, name)
+	case opIn, opNotIn:
+		values := make([]string, len(c.values))
+		for i, v := range c.values {
+			values[i] = formatOperand(v)
+		}
+		return fmt.Sprintf("%s %s (%s)", c.column, name, strings.Join(values, ", "))
+	default:
+		return fmt.Sprintf("%s %s %s", c.column, name, formatOperand(c.values[0]))
+	}
+}
+
+func formatOperand(v interface{}) string {
+	if s, ok := v.(string); ok {
+		return fmt.Sprintf("%q", s)
+	}
+	return fmt.Sprint(v)
+}
+
+func (c *comparison) negate() Predicate {
+	inverse := map[compareOp]compareOp{
+		opEq: opNe, opNe: opEq, opLt: opGe, opGe: opLt, opLe: opGt, opGt: opLe,
+		opIn: opNotIn, opNotIn: opIn, opIsNull: opIsNotNull, opIsNotNull: opIsNull,
+	}
+	return &comparison{column: c.column, op: inverse[c.op], values: c.values}
+}
+
+func (c *comparison) columns(out []string) []string {
+	return append(out, c.column)
+}
+
+func (c *comparison) match(row map[string]interface{}) (bool, error) {
+	v := row[c.column]
+	switch c.op {
+	case opIsNull:
+		return v == nil, nil
+	case opIsNotNull:
+		return v != nil, nil
+	}
+	if v == nil {
+		return false, nil
+	}
+
+	if c.op == opIn || c.op == opNotIn {
+		found := false
+		for _, operand := range c.values {
+			cmp, ordered, err := compareValues(v, operand)
+			if err != nil {
+				return false, fmt.Errorf("%s: %v", c, err)
+			}
+			if ordered && cmp == 0 {
+				found = true
+				break
+			}
+		}
+		return found == (c.op == opIn), nil
+	}
+
+	cmp, ordered, err := compareValues(v, c.values[0])
+	if err != nil {
+		return false, fmt.Errorf("%s: %v", c, err)
+	}
+	if !ordered {
+		// NaN: only != holds.
+		return c.op == opNe, nil
+	}
+	return c.op.holds(cmp), nil
+}
+
+// holds reports whether the result of comparing a value with the operand
+// satisfies a binary comparison.
+func (op compareOp) holds(cmp int) bool {
+	switch op {
+	case opEq:
+		return cmp == 0
+	case opNe:
+		return cmp != 0
+	case opLt:
+		return cmp < 0
+	case opLe:
+		return cmp <= 0
+	case opGt:
+		return cmp > 0
+	case opGe:
+		return cmp >= 0
+	}
+	return false
+}
+
+func (c *comparison) mayMatch(ranges map[string]*valueRange) bool {
+	r := ranges[c.column]
+	if r == nil {
+		return true
+	}
+
+	switch c.op {
+	case opIsNull:
+		return r.nullCount != 0
+	case opIsNotNull:
+		return !r.allNull
+	}
+	if r.allNull {
+		return false
+	}
+
+	// Missing bounds, an error or NaN make the comparison inconclusive, so
+	// the range is kept.
+	minCmp := func(v interface{}) (int, bool) {
+		if !r.hasBounds {
+			return 0, false
+		}
+		cmp, ordered, err := compareValues(r.min, v)
+		return cmp, ordered && err == nil
+	}
+	maxCmp := func(v interface{}) (int, bool) {
+		if !r.hasBounds {
+			return 0, false
+		}
+		cmp, ordered, err := compareValues(r.max, v)
+		return cmp, ordered && err == nil
+	}
+	// within reports whether v may lie in [min, max] and, if the range has a
+	// bloom filter, may be in it.
+	within := func(v interface{}) bool {
+		lo, ok1 := minCmp(v)
+		hi, ok2 := maxCmp(v)
+		if ok1 && ok2 && (lo > 0 || hi < 0) {
+			return false
+		}
+		if r.bloom != nil {
+			found, err := r.bloom.MightContain(v)
+			return found || err != nil
+		}
+		return true
+	}
+	// only reports whether every value in the range equals v. Floating-point
+	// bounds leave out NaNs, which differ from every value, so they never do.
+	only := func(v interface{}) bool {
+		switch r.min.(type) {
+		case float32, float64:
+			return false
+		}
+		lo, ok1 := minCmp(v)
+		hi, ok2 := maxCmp(v)
+		return ok1 && ok2 && lo == 0 && hi == 0
+	}
+
+	switch c.op {
+	case opEq:
+		return within(c.values[0])
+	case opNe:
+		return !only(c.values[0])
+	case opLt:
+		cmp, ok := minCmp(c.values[0])
+		return !ok || cmp < 0
+	case opLe:
+		cmp, ok := minCmp(c.values[0])
+		return !ok || cmp <= 0
+	case opGt:
+		cmp, ok := maxCmp(c.values[0])
+		return !ok || cmp > 0
+	case opGe:
+		cmp, ok := maxCmp(c.values[0])
+		return !ok || cmp >= 0
+	case opIn:
+		for _, v := range c.values {
+			if within(v) {
+				return true
+			}
+		}
+		return false
+	case opNotIn:
+		for _, v := range c.values {
+			if only(v) {
+				return false
+			}
+		}
+		return true
+	}
+	return true
+}
+
+func (c *comparison) candidateRows(pages map[string][]pageRange, numRows int64) []rowRange {
+	columnPages, ok := pages[c.column]
+	if !ok {
+		return []rowRange{{0, numRows}}
+	}
+
+	var out []rowRange
+	for _, page := range columnPages {
+		if c.mayMatch(map[string]*valueRange{c.column: page.values}) {
+			out = unionRanges(out, []rowRange{page.rows})
+		}
+	}
+	return out
+}
+
+// junction is an AND or OR of predicates.
+type junction struct {
+	and      bool
+	children []Predicate
+}
+
+func (j *junction) String() string {
+	if len(j.children) == 0 {
+		if j.and {
+			return "TRUE"
+		}
+		return "FALSE"
+	}
+	sep := " OR "
+	if j.and {
+		sep = " AND "
+	}
+	parts := make([]string, len(j.children))
+	for i, child := range j.children {
+		parts[i] = child.String()
+		if _, ok := child.(*junction); ok {
+			parts[i] = "(" + parts[i] + ")"
+		}
+	}
+	return strings.Join(parts, sep)
+}
+
+func (j *junction) negate() Predicate {
+	children := make([]Predicate, len(j.children))
+	for i, child := range j.children {
+		children[i] = child.negate()
+	}
+	return &junction{and: !j.and, children: children}
+}
+
+func (j *junction) columns(out []string) []string {
+	for _, child := range j.children {
+		out = child.columns(out)
+	}
+	return out
+}
+
+func (j *junction) match(row map[string]interface{}) (bool, error) {
+	for _, child := range j.children {
+		ok, err := child.match(row)
+		if err != nil {
+			return false, err
+		}
+		if ok != j.and {
+			return ok, nil
+		}
+	}
+	return j.and, nil
+}
+
+func (j *junction) mayMatch(ranges map[string]*valueRange) bool {
+	for _, child := range j.children {
+		if child.mayMatch(ranges) != j.and {
+			return !j.and
+		}
+	}
+	return j.and
+}
+
+func (j *junction) candidateRows(pages map[string][]pageRange, numRows int64) []rowRange {
+	if j.and {
+		out := []rowRange{{0, numRows}}
+		for _, child := range j.children {
+			out = intersectRanges(out, child.candidateRows(pages, numRows))
+		}
+		return out
+	}
+
+	var out []rowRange
+	for _, child := range j.children {
+		out = unionRanges(out, child.candidateRows(pages, numRows))
+	}
+	return out
+}
+
+// compareValues compares a column value with an operand and returns -1, 0 or
+// 1. ordered is false if either is NaN. Numbers of any Go type compare by
+// value, as do Decimals with numbers.
+func compareValues(a, b interface{}) (cmp int, ordered bool, err error) {
+	switch x := a.(type) {
+	case string:
+		if y, ok := b.(string); ok {
+			return strings.Compare(x, y), true, nil
+		}
+	case bool:
+		if y, ok := b.(bool); ok {
+			return compareBools(x, y), true, nil
+		}
+	case time.Time:
+		if y, ok := b.(time.Time); ok {
+			return x.Compare(y), true, nil
+		}
+	case Date:
+		if y, ok := b.(Date); ok {
+			return x.Time().Compare(y.Time()), true, nil
+		}
+	case UUID:
+		if y, ok := b.(UUID); ok {
+			return bytes.Compare(x[:], y[:]), true, nil
+		}
+	case Int96:
+		// Legacy INT96 timestamps compare as instants, also with a time.Time.
+		switch y := b.(type) {
+		case Int96:
+			return x.Time().Compare(y.Time()), true, nil
+		case time.Time:
+			return x.Time().Compare(y), true, nil
+		}
+	}
+
+	if ra, ok := toRat(a); ok {
+		if rb, ok := toRat(b); ok {
+			return ra.Cmp(rb), true, nil
+		}
+	}
+	if isNumber(a) && isNumber(b) {
+		// Only NaN fails to convert to a Rat.
+		return 0, false, nil
+	}
+	return 0, false, fmt.Errorf("cannot compare %T with %T", a, b)
+}
+
+func compareBools(x, y bool) int {
+	switch {
+	case x == y:
+		return 0
+	case !x:
+		return -1
+	default:
+		return 1
+	}
+}
+
+func isNumber(v interface{}) bool {
+	if _, ok := v.(Decimal); ok {
+		return true
+	}
+	switch reflect.ValueOf(v).Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64,
+		reflect.Float32, reflect.Float64:
+		return true
+	}
+	return false
+}
+
+// toRat converts a number, including named types such as TimeOfDay and
+// Decimal, to an exact rational. It fails for NaN and infinities.
+func toRat(v interface{}) (*big.Rat, bool) {
+	if d, ok := v.(Decimal); ok {
+		return d.Rat(), true
+	}
+
+	rv := reflect.ValueOf(v)
+	switch rv.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return new(big.Rat).SetInt64(rv.Int()), true
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return new(big.Rat).SetInt(new(big.Int).SetUint64(rv.Uint())), true
+	case reflect.Float32, reflect.Float64:
+		f := rv.Float()
+		if math.IsNaN(f) {
+			return nil, false
+		}
+		if math.IsInf(f, 0) {
+			// Larger than any finite float, which is all the comparison needs.
+			limit := new(big.Rat).SetFloat64(math.MaxFloat64)
+			limit.Mul(limit, big.NewRat(2, 1))
+			if f < 0 {
+				limit.Neg(limit)
+			}
+			return limit, true
+		}
+		return new(big.Rat).SetFloat64(f), true
+	}
+	return nil, false
+}
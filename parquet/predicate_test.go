@@ -0,0 +1,181 @@
+package parquet
+
+import (
+	"math"
+	"math/big"
+	"testing"
+	"time"
+)
+
+func TestCompareValues(t *testing.T) {
+	day := time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC)
+	tests := []struct {
+		name    string
+		a, b    interface{}
+		cmp     int
+		ordered bool
+		err     bool
+	}{
+		{"strings", "apple", "banana", -1, true, false},
+		{"bools", true, false, 1, true, false},
+		{"mixed ints", int32(7), int64(7), 0, true, false},
+		{"int and float", int64(2), 2.5, -1, true, false},
+		{"unsigned", uint64(math.MaxUint64), int64(-1), 1, true, false},
+		{"decimal and int", Decimal{Unscaled: big.NewInt(150), Scale: 2}, 1, 1, true, false},
+		{"decimal and float", Decimal{Unscaled: big.NewInt(150), Scale: 2}, 1.5, 0, true, false},
+		{"infinity", math.Inf(1), math.MaxFloat64, 1, true, false},
+		{"NaN", math.NaN(), 1.0, 0, false, false},
+		{"NaN operand", int32(1), math.NaN(), 0, false, false},
+		{"dates", Date{Year: 2024, Month: 2, Day: 29}, Date{Year: 2024, Month: 3, Day: 1}, -1, true, false},
+		{"timestamps", day, day.Add(time.Second), -1, true, false},
+		{"int96 and time", Int96{0, 0, 0, 0, 0, 0, 0, 0, 0x8c, 0x3d, 0x25, 0x00}, time.Unix(0, 0), 0, true, false},
+		{"uuids", UUID{1}, UUID{0, 1}, 1, true, false},
+		{"string and int", "1", 1, 0, false, true},
+		{"date and time", Date{Year: 2024, Month: 3, Day: 1}, day, 0, false, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cmp, ordered, err := compareValues(tt.a, tt.b)
+			if (err != nil) != tt.err {
+				t.Fatalf("got error %v, want error %v", err, tt.err)
+			}
+			if cmp != tt.cmp || ordered != tt.ordered {
+				t.Errorf("got %d, %v; want %d, %v", cmp, ordered, tt.cmp, tt.ordered)
+			}
+		})
+	}
+}
+
+func TestNotPushdown(t *testing.T) {
+	tests := []struct {
+		p    Predicate
+		want string
+	}{
+		{Not(Eq("a", 1)), "a != 1"},
+		{Not(Lt("a", 1)), "a >= 1"},
+		{Not(Ge("a", 1)), "a < 1"},
+		{Not(In("a", "x", "y")), `a NOT IN ("x", "y")`},
+		{Not(IsNull("a")), "a IS NOT NULL"},
+		{Not(Not(Gt("a", 1))), "a > 1"},
+		{Not(And(Lt("a", 1), IsNull("b"))), "a >= 1 OR b IS NOT NULL"},
+		{Not(Or(Eq("a", 1), And(Eq("b", 2), Le("c", 3)))), "a != 1 AND (b != 2 OR c > 3)"},
+		{Not(And()), "FALSE"},
+	}
+	for _, tt := range tests {
+		if got := tt.p.String(); got != tt.want {
+			t.Errorf("got %s, want %s", got, tt.want)
+		}
+	}
+}
+
+func TestPredicateMatch(t *testing.T) {
+	row := map[string]interface{}{"n": int64(5), "s": "b", "f": math.NaN(), "null": nil}
+	tests := []struct {
+		p    Predicate
+		want bool
+	}{
+		{Eq("n", 5), true},
+		{Eq("n", 5.0), true},
+		{Lt("n", int32(5)), false},
+		{Le("n", 5), true},
+		{Gt("s", "a"), true},
+		{In("s", "a", "b"), true},
+		{Not(In("s", "a", "b")), false},
+		{IsNull("null"), true},
+		{IsNotNull("n"), true},
+		// Comparisons with NULL never match, negated or not.
+		{Eq("null", 1), false},
+		{Ne("null", 1), false},
+		{Not(Lt("null", 1)), false},
+		// NaN is unordered: only != holds.
+		{Eq("f", math.NaN()), false},
+		{Ne("f", 1.0), true},
+		{Gt("f", 1.0), false},
+		{And(Eq("n", 5), Eq("s", "b")), true},
+		{And(Eq("n", 5), Eq("s", "c")), false},
+		{Or(Eq("n", 4), Eq("s", "b")), true},
+		{And(), true},
+		{Or(), false},
+	}
+	for _, tt := range tests {
+		got, err := tt.p.match(row)
+		if err != nil {
+			t.Fatalf("%s: %v", tt.p, err)
+		}
+		if got != tt.want {
+			t.Errorf("%s: got %v, want %v", tt.p, got, tt.want)
+		}
+	}
+
+	if _, err := Eq("s", 1).match(row); err == nil {
+		t.Error("comparing a string with a number: expected an error")
+	}
+}
+
+func TestPredicateMayMatch(t *testing.T) {
+	bloom := newTestBloomFilter(t, SchemaElement{Type: 2}, 32, int64(10), int64(12), int64(20))
+	// An empty filter, which rules out every value it can hash.
+	decimalBloom := &BloomFilter{
+		elem:   SchemaElement{Type: 6, LogicalType: &LogicalType{Kind: LogicalTypeDecimal, Decimal: &DecimalType{Scale: 0, Precision: 9}}},
+		bitset: make([]byte, 32),
+	}
+	ranges := map[string]*valueRange{
+		"n":       {min: int64(10), max: int64(20), hasBounds: true},
+		"single":  {min: int64(10), max: int64(10), hasBounds: true},
+		"float":   {min: 1.0, max: 1.0, hasBounds: true},
+		"nulls":   {min: int64(10), max: int64(20), hasBounds: true, nullCount: 3},
+		"unknown": {nullCount: -1},
+		"allnull": {nullCount: 5, allNull: true},
+		"bloom":   {min: int64(10), max: int64(20), hasBounds: true, bloom: bloom},
+		"decimal": {nullCount: -1, bloom: decimalBloom},
+	}
+	tests := []struct {
+		p    Predicate
+		want bool
+	}{
+		{Eq("n", 5), false},
+		{Eq("n", 15), true},
+		{Eq("n", 20.0), true},
+		{Lt("n", 10), false},
+		{Le("n", 10), true},
+		{Gt("n", 20), false},
+		{Ge("n", 20), true},
+		{Ne("n", 10), true},
+		{In("n", 1, 2, 30), false},
+		{In("n", 1, 15), true},
+		{Not(In("n", 15)), true},
+		{Ne("single", 10), false},
+		{Not(In("single", 9, 10)), false},
+		{Eq("single", 10), true},
+		// Float bounds leave out NaNs, so != may always match.
+		{Ne("float", 1.0), true},
+		{IsNull("n"), false},
+		{IsNull("nulls"), true},
+		{IsNotNull("nulls"), true},
+		{Gt("unknown", 100), true},
+		{IsNull("unknown"), true},
+		{IsNull("allnull"), true},
+		{IsNotNull("allnull"), false},
+		{Eq("allnull", 1), false},
+		{Eq("missing", 1), true},
+		// Comparisons that fail are kept.
+		{Lt("n", "x"), true},
+		{And(Eq("n", 15), Eq("single", 11)), false},
+		{Or(Eq("n", 5), Eq("single", 10)), true},
+		{Eq("bloom", 12), true},
+		{Eq("bloom", 15), false},
+		{In("bloom", 11, 15), false},
+		{In("bloom", 11, 20), true},
+		{Eq("bloom", 25), false},
+		{And(Eq("n", 15), Eq("bloom", 15)), false},
+		{Or(Eq("n", 5), Eq("bloom", 12)), true},
+		// A value the filter cannot hash is kept.
+		{Eq("decimal", 5), true},
+		{Eq("bloom", "x"), true},
+	}
+	for _, tt := range tests {
+		if got := tt.p.mayMatch(ranges); got != tt.want {
+			t.Errorf("%s: got %v, want %v", tt.p, got, tt.want)
+		}
+	}
+}
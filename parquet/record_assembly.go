@@ -0,0 +1,272 @@
+package parquet
+
+import (
+	"fmt"
+)
+
+// assembleRecords rebuilds the rows of a row group from its striped columns
+// (Dremel record assembly). Rows are returned as maps from top-level field name
+// to value:
+//
+//   - a group (struct) becomes a map[string]interface{}
+//   - a LIST-annotated group or a bare REPEATED field becomes a []interface{}
+//   - a MAP-annotated group becomes a map[interface{}]interface{}
+//   - a NULL optional field is nil; an empty or NULL list is an empty slice
+//     or nil respectively
+//
+// Every leaf column is replayed independently into the same row tree: the
+// repetition level of a slot says at which repeated ancestor a new element
+// starts, the definition level says how deep along the path the slot is
+// defined. Every column must hold numRows rows; without columns the rows are
+// empty.
+func assembleRecords(root *SchemaNode, columns []*columnData, numRows int) ([]map[string]interface{}, error) {
+	records := make([]map[string]interface{}, 0, numRows)
+	if len(columns) == 0 {
+		for len(records) < numRows {
+			records = append(records, map[string]interface{}{})
+		}
+	}
+	for _, col := range columns {
+		if rows := col.numRows(); rows != numRows {
+			return nil, fmt.Errorf("assembling column %s: got %d rows, expected %d", col.leaf.DottedPath(), rows, numRows)
+		}
+		var err error
+		records, err = insertColumn(records, col)
+		if err != nil {
+			return nil, fmt.Errorf("assembling column %s: %v", col.leaf.DottedPath(), err)
+		}
+	}
+
+	out := make([]map[string]interface{}, 0, len(records))
+	for _, record := range records {
+		out = append(out, convertGroup(root, record))
+	}
+	return out, nil
+}
+
+// insertColumn replays one leaf column into the raw row tree. In the raw tree
+// every group is a map[string]interface{} and every REPEATED node a
+// []interface{} stored under its name in the parent group.
+func insertColumn(records []map[string]interface{}, col *columnData) ([]map[string]interface{}, error) {
+	leaf := col.leaf
+
+	var path []*SchemaNode
+	for node := leaf; node.Parent != nil; node = node.Parent {
+		path = append([]*SchemaNode{node}, path...)
+	}
+
+	// indices[k] is the current element index of the list at repetition level k.
+	indices := make([]int, leaf.MaxRepetitionLevel+1)
+	recordIdx := -1
+	next := 0
+
+	for slot, defLevel := range col.definitionLevels {
+		repLevel := int(col.repetitionLevels[slot])
+		def := int(defLevel)
+		if repLevel > leaf.MaxRepetitionLevel {
+			return nil, fmt.Errorf("repetition level %d exceeds maximum %d", repLevel, leaf.MaxRepetitionLevel)
+		}
+		if def > leaf.MaxDefinitionLevel {
+			return nil, fmt.Errorf("definition level %d exceeds maximum %d", def, leaf.MaxDefinitionLevel)
+		}
+
+		if repLevel == 0 {
+			recordIdx++
+			if recordIdx == len(records) {
+				records = append(records, map[string]interface{}{})
+			}
+			for k := range indices {
+				indices[k] = 0
+			}
+		} else {
+			if recordIdx < 0 {
+				return nil, fmt.Errorf("first slot has repetition level %d, expected 0", repLevel)
+			}
+			indices[repLevel]++
+			for k := repLevel + 1; k < len(indices); k++ {
+				indices[k] = 0
+			}
+		}
+
+		var value interface{}
+		if def == leaf.MaxDefinitionLevel {
+			if next >= len(col.values) {
+				return nil, fmt.Errorf("definition levels reference more than %d values", len(col.values))
+			}
+			value = col.values[next]
+			next++
+		}
+
+		container := records[recordIdx]
+		for _, node := range path {
+			name := node.Element.Name
+
+			if node.MaxDefinitionLevel > def {
+				// Undefined from here down: NULL, or an empty list for a repeated node.
+				if _, ok := container[name]; !ok {
+					if node.IsRepeated() {
+						container[name] = []interface{}{}
+					} else {
+						container[name] = nil
+					}
+				}
+				break
+			}
+
+			if node.IsRepeated() {
+				list, _ := container[name].([]interface{})
+				idx := indices[node.MaxRepetitionLevel]
+				if idx > len(list) {
+					return nil, fmt.Errorf("list %s skips from element %d to %d", node.DottedPath(), len(list), idx)
+				}
+				if idx == len(list) {
+					var elem interface{} = value
+					if !node.IsLeaf() {
+						elem = map[string]interface{}{}
+					}
+					list = append(list, elem)
+					container[name] = list
+				}
+				if node.IsLeaf() {
+					break
+				}
+				container = list[idx].(map[string]interface{})
+				continue
+			}
+
+			if node.IsLeaf() {
+				container[name] = value
+				break
+			}
+			child, ok := container[name].(map[string]interface{})
+			if !ok {
+				child = map[string]interface{}{}
+				container[name] = child
+			}
+			container = child
+		}
+	}
+
+	return records, nil
+}
+
+// convertGroup turns a raw group into its output form, applying LIST and MAP
+// annotations of the children. Children without an assembled column are left
+// out.
+func convertGroup(node *SchemaNode, raw map[string]interface{}) map[string]interface{} {
+	out := make(map[string]interface{}, len(node.Children))
+	for _, child := range node.Children {
+		value, ok := raw[child.Element.Name]
+		if !ok {
+			continue
+		}
+		out[child.Element.Name] = convertField(child, value)
+	}
+	return out
+}
+
+// convertField converts the raw value stored under node in its parent group.
+func convertField(node *SchemaNode, raw interface{}) interface{} {
+	if raw == nil {
+		return nil
+	}
+
+	if node.IsRepeated() {
+		// A bare repeated field: each element is the node itself.
+		list := raw.([]interface{})
+		out := make([]interface{}, 0, len(list))
+		for _, elem := range list {
+			out = append(out, convertElement(node, elem))
+		}
+		return out
+	}
+	if node.IsLeaf() {
+		return raw
+	}
+
+	group := raw.(map[string]interface{})
+	switch {
+	case isListNode(node):
+		return convertList(node, group)
+	case isMapNode(node):
+		return convertMap(node, group)
+	default:
+		return convertGroup(node, group)
+	}
+}
+
+// convertElement converts a single element of a repeated node.
+func convertElement(node *SchemaNode, raw interface{}) interface{} {
+	if node.IsLeaf() || raw == nil {
+		return raw
+	}
+	return convertGroup(node, raw.(map[string]interface{}))
+}
+
+func isListNode(node *SchemaNode) bool {
+	lt := node.Element.ResolvedLogicalType()
+	return lt != nil && lt.Kind == LogicalTypeList && len(node.Children) == 1 && node.Children[0].IsRepeated()
+}
+
+func isMapNode(node *SchemaNode) bool {
+	// Some old writers annotate the map group itself with MAP_KEY_VALUE.
+	lt := node.Element.ResolvedLogicalType()
+	ct := node.Element.ConvertedType
+	isMap := (lt != nil && lt.Kind == LogicalTypeMap) || (ct != nil && *ct == 2)
+	if !isMap || len(node.Children) != 1 {
+		return false
+	}
+	// The key must be a primitive so that it can index a Go map; other
+	// layouts are left as a list of key/value groups.
+	kv := node.Children[0]
+	return kv.IsRepeated() && !kv.IsLeaf() && len(kv.Children) >= 1 && kv.Children[0].IsLeaf()
+}
+
+// convertList handles both the standard 3-level LIST layout
+// (<list> / repeated group list / element) and the legacy 2-level layouts
+// described in the Parquet LogicalTypes backward-compatibility rules.
+func convertList(node *SchemaNode, group map[string]interface{}) interface{} {
+	repeated := node.Children[0]
+	list, _ := group[repeated.Element.Name].([]interface{})
+
+	// The repeated node is itself the element unless it is a group with a
+	// single field that is not named "array" or "<list>_tuple".
+	elementIsRepeated := repeated.IsLeaf() || len(repeated.Children) > 1 ||
+		repeated.Element.Name == "array" || repeated.Element.Name == node.Element.Name+"_tuple"
+
+	out := make([]interface{}, 0, len(list))
+	for _, elem := range list {
+		if elementIsRepeated {
+			out = append(out, convertElement(repeated, elem))
+			continue
+		}
+		element := repeated.Children[0]
+		out = append(out, convertField(element, elem.(map[string]interface{})[element.Element.Name]))
+	}
+	return out
+}
+
+// convertMap turns the repeated key_value group of a MAP into a Go map. Keys
+// are always primitive (and thus comparable) per the spec.
+func convertMap(node *SchemaNode, group map[string]interface{}) interface{} {
+	kv := node.Children[0]
+	list, _ := group[kv.Element.Name].([]interface{})
+
+	key := kv.Children[0]
+	var value *SchemaNode
+	if len(kv.Children) > 1 {
+		value = kv.Children[1]
+	}
+
+	out := make(map[interface{}]interface{}, len(list))
+	for _, elem := range list {
+		entry := elem.(map[string]interface{})
+		k := convertField(key, entry[key.Element.Name])
+		var v interface{}
+		if value != nil {
+			v = convertField(value, entry[value.Element.Name])
+		}
+		out[k] = v
+	}
+	return out
+}
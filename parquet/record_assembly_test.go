@@ -0,0 +1,131 @@
+package parquet
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestAssembleRecords(t *testing.T) {
+	root, leaves := testSchema(t)
+
+	// Three rows:
+	//   {id: 1, name: {first: "a"}, tags: ["x", null], phones: [5, 6], attrs: {"k": 1}}
+	//   {id: 2, name: null, tags: [], phones: [], attrs: null}
+	//   {id: 3, name: {first: null}, tags: null, phones: [7], attrs: {"k": null, "j": 2}}
+	columns := []*columnData{
+		{leaf: leaves[0], values: []interface{}{int64(1), int64(2), int64(3)},
+			repetitionLevels: []uint32{0, 0, 0}, definitionLevels: []uint32{0, 0, 0}},
+		{leaf: leaves[1], values: []interface{}{"a"},
+			repetitionLevels: []uint32{0, 0, 0}, definitionLevels: []uint32{2, 0, 1}},
+		{leaf: leaves[2], values: []interface{}{"x"},
+			repetitionLevels: []uint32{0, 1, 0, 0}, definitionLevels: []uint32{3, 2, 1, 0}},
+		{leaf: leaves[3], values: []interface{}{int32(5), int32(6), int32(7)},
+			repetitionLevels: []uint32{0, 1, 0, 0}, definitionLevels: []uint32{1, 1, 0, 1}},
+		{leaf: leaves[4], values: []interface{}{"k", "k", "j"},
+			repetitionLevels: []uint32{0, 0, 0, 1}, definitionLevels: []uint32{2, 0, 2, 2}},
+		{leaf: leaves[5], values: []interface{}{int32(1), int32(2)},
+			repetitionLevels: []uint32{0, 0, 0, 1}, definitionLevels: []uint32{3, 0, 2, 3}},
+	}
+
+	got, err := assembleRecords(root, columns, 3)
+	if err != nil {
+		t.Fatalf("assembleRecords: %v", err)
+	}
+	want := []map[string]interface{}{
+		{
+			"id":     int64(1),
+			"name":   map[string]interface{}{"first": "a"},
+			"tags":   []interface{}{"x", nil},
+			"phones": []interface{}{int32(5), int32(6)},
+			"attrs":  map[interface{}]interface{}{"k": int32(1)},
+		},
+		{
+			"id":     int64(2),
+			"name":   nil,
+			"tags":   []interface{}{},
+			"phones": []interface{}{},
+			"attrs":  nil,
+		},
+		{
+			"id":     int64(3),
+			"name":   map[string]interface{}{"first": nil},
+			"tags":   nil,
+			"phones": []interface{}{int32(7)},
+			"attrs":  map[interface{}]interface{}{"k": nil, "j": int32(2)},
+		},
+	}
+	for i := range want {
+		if i >= len(got) {
+			t.Fatalf("got %d rows, want %d", len(got), len(want))
+		}
+		if !reflect.DeepEqual(got[i], want[i]) {
+			t.Errorf("row %d:\ngot  %#v\nwant %#v", i, got[i], want[i])
+		}
+	}
+	if len(got) != len(want) {
+		t.Errorf("got %d rows, want %d", len(got), len(want))
+	}
+}
+
+func TestAssembleRecordsGroupMapKey(t *testing.T) {
+	// A MAP whose key is a group cannot become a Go map; it is returned as
+	// its list of key/value groups instead.
+	numChildren := int32(1)
+	root, leaves, err := buildSchemaTree([]SchemaElement{
+		{Name: "schema", NumChildren: &numChildren},
+		testGroup("m", 1, 1, 1), // MAP
+		testGroup("key_value", 2, -1, 2),
+		testGroup("key", 0, -1, 1),
+		testLeaf("a", 0, 1),
+		testLeaf("value", 1, 1),
+	})
+	if err != nil {
+		t.Fatalf("buildSchemaTree: %v", err)
+	}
+	columns := []*columnData{
+		{leaf: leaves[0], values: []interface{}{int32(1)}, repetitionLevels: []uint32{0}, definitionLevels: []uint32{2}},
+		{leaf: leaves[1], values: []interface{}{int32(2)}, repetitionLevels: []uint32{0}, definitionLevels: []uint32{3}},
+	}
+
+	got, err := assembleRecords(root, columns, 1)
+	if err != nil {
+		t.Fatalf("assembleRecords: %v", err)
+	}
+	want := map[string]interface{}{
+		"m": map[string]interface{}{
+			"key_value": []interface{}{
+				map[string]interface{}{"key": map[string]interface{}{"a": int32(1)}, "value": int32(2)},
+			},
+		},
+	}
+	if len(got) != 1 || !reflect.DeepEqual(got[0], want) {
+		t.Errorf("got %#v, want %#v", got, want)
+	}
+}
+
+func TestAssembleRecordsErrors(t *testing.T) {
+	root, leaves := testSchema(t)
+	id := leaves[0]
+
+	tests := []struct {
+		name    string
+		col     *columnData
+		numRows int
+	}{
+		{"row count mismatch", &columnData{leaf: id, values: []interface{}{int64(1)},
+			repetitionLevels: []uint32{0}, definitionLevels: []uint32{0}}, 2},
+		{"missing values", &columnData{leaf: leaves[1], values: nil,
+			repetitionLevels: []uint32{0}, definitionLevels: []uint32{2}}, 1},
+		{"definition level too high", &columnData{leaf: leaves[1], values: []interface{}{"a"},
+			repetitionLevels: []uint32{0}, definitionLevels: []uint32{3}}, 1},
+		{"repetition level too high", &columnData{leaf: leaves[3], values: []interface{}{int32(1), int32(2)},
+			repetitionLevels: []uint32{0, 2}, definitionLevels: []uint32{1, 1}}, 1},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := assembleRecords(root, []*columnData{tt.col}, tt.numRows); err == nil {
+				t.Error("expected an error")
+			}
+		})
+	}
+}
@@ -0,0 +1,184 @@
+package parquet
+
+import (
+	"encoding/binary"
+	"fmt"
+	"io"
+)
+
+// maxRLEBitWidth is the widest value the hybrid encoding is used for
+// (dictionary indices and levels never need more than 32 bits).
+const maxRLEBitWidth = 32
+
+// decodeRLELevels decodes RLE/bit-packed levels from a Parquet page.
+// Format: [4 bytes length (LE)] [encoded bytes]
+// Returns: decoded levels and remaining bytes after the level section.
+func decodeRLELevels(data []byte, numValues int, bitWidth uint) ([]uint32, []byte, error) {
+	if len(data) < 4 {
+		return nil, data, io.ErrUnexpectedEOF
+	}
+
+	// Read encoded section length (4 bytes, little-endian).
+	encodedLength := int(binary.LittleEndian.Uint32(data[0:4]))
+	if encodedLength < 0 || 4+encodedLength > len(data) {
+		return nil, data, fmt.Errorf("invalid encoded length: %d", encodedLength)
+	}
+
+	// Decode levels.
+	encodedData := data[4 : 4+encodedLength]
+	levels, _, err := decodeRLEHybrid(encodedData, numValues, bitWidth)
+	if err != nil {
+		return nil, data, err
+	}
+
+	// Return decoded levels and the remaining bytes.
+	return levels, data[4+encodedLength:], nil
+}
+
+// decodeBitPackedLevels decodes levels in the deprecated BIT_PACKED encoding:
+// numValues values of bitWidth bits, packed from the most significant bit of
+// each byte and not length-prefixed. It also returns the bytes after them.
+func decodeBitPackedLevels(data []byte, numValues int, bitWidth uint) ([]uint32, []byte, error) {
+	byteCount := (numValues*int(bitWidth) + 7) / 8
+	if byteCount > len(data) {
+		return nil, data, fmt.Errorf("bit-packed levels need %d bytes, have %d: %w", byteCount, len(data), io.ErrUnexpectedEOF)
+	}
+
+	levels := make([]uint32, numValues)
+	for i := range levels {
+		var v uint32
+		for b := uint(0); b < bitWidth; b++ {
+			pos := uint(i)*bitWidth + b
+			v = v<<1 | uint32(data[pos/8]>>(7-pos%8))&1
+		}
+		levels[i] = v
+	}
+	return levels, data[byteCount:], nil
+}
+
+// decodeRLEBooleanValues decodes BOOLEAN data values stored with the RLE
+// encoding. The layout is the same as for levels, with a bit width of 1.
+func decodeRLEBooleanValues(data []byte, dataType int32, numValues int) ([]interface{}, error) {
+	if dataType != 0 {
+		return nil, fmt.Errorf("RLE encoding is not valid for data values of type %d", dataType)
+	}
+
+	decoded, _, err := decodeRLELevels(data, numValues, 1)
+	if err != nil {
+		return nil, fmt.Errorf("decoding RLE boolean values: %v", err)
+	}
+	if len(decoded) < numValues {
+		return nil, fmt.Errorf("decoded %d RLE boolean values, expected %d", len(decoded), numValues)
+	}
+
+	values := make([]interface{}, 0, numValues)
+	for _, v := range decoded[:numValues] {
+		values = append(values, v != 0)
+	}
+	return values, nil
+}
+
+// decodeRLEHybrid decodes up to numValues values of the RLE/bit-packed hybrid
+// encoding and returns them together with the number of bytes consumed.
+// Implementation is based on parquet-go's RLE decoder.
+//
+// Each run starts with a varint header h. If h&1 == 1 the run holds h>>1 groups
+// of 8 bit-packed values; otherwise it repeats a single value h>>1 times, stored
+// in ceil(bitWidth/8) little-endian bytes.
+func decodeRLEHybrid(src []byte, numValues int, bitWidth uint) ([]uint32, int, error) {
+	if bitWidth > maxRLEBitWidth {
+		return nil, 0, fmt.Errorf("bit width %d exceeds maximum of %d", bitWidth, maxRLEBitWidth)
+	}
+
+	dst := make([]uint32, 0, numValues)
+	valueBytes := int(bitWidth+7) / 8
+	i := 0
+
+	for i < len(src) && len(dst) < numValues {
+		// Read block header varint.
+		u, n := binary.Uvarint(src[i:])
+		if n == 0 {
+			return dst, i, fmt.Errorf("decoding run-length block header: %w", io.ErrUnexpectedEOF)
+		}
+		if n < 0 {
+			return dst, i, fmt.Errorf("overflow after decoding %d/%d bytes of run-length block header", -n+i, len(src))
+		}
+		i += n
+
+		// count = number of values (or groups), bitpacked = bit-packed mode flag.
+		count := uint(u >> 1)
+		bitpacked := (u & 1) != 0
+
+		if count > 16*1024*1024 { // maxSupportedValueCount
+			return dst, i, fmt.Errorf("decoded run-length block cannot have more than %d values", 16*1024*1024)
+		}
+
+		if bitpacked {
+			// Bit-packed mode: count*8 values, each of width bitWidth bits.
+			count *= 8
+			byteCount := int((count*bitWidth + 7) / 8) // round up
+			j := i + byteCount
+
+			if j > len(src) {
+				return dst, i, fmt.Errorf("decoding bit-packed block of %d values: %w", count, io.ErrUnexpectedEOF)
+			}
+
+			// The last group may be padded past numValues; only keep what is needed.
+			keep := min(int(count), numValues-len(dst))
+			for k := 0; k < keep; k++ {
+				dst = append(dst, uint32(bitsAt(src[i:j], uint(k)*bitWidth, bitWidth)))
+			}
+			i = j
+		} else {
+			// RLE mode: repeat a single value count times.
+			if i+valueBytes > len(src) {
+				return dst, i, fmt.Errorf("decoding run-length block of %d values: %w", count, io.ErrUnexpectedEOF)
+			}
+
+			var word uint32
+			for k := 0; k < valueBytes; k++ {
+				word |= uint32(src[i+k]) << (8 * k)
+			}
+			i += valueBytes
+
+			// Append the same value count times.
+			for k := uint(0); k < count && len(dst) < numValues; k++ {
+				dst = append(dst, word)
+			}
+		}
+	}
+
+	return dst, i, nil
+}
+
+// bitsAt returns bitWidth (at most 64) bits of src starting at bit position
+// bitPos, in the LSB-first order used by Parquet bit packing.
+func bitsAt(src []byte, bitPos uint, bitWidth uint) uint64 {
+	var value uint64
+	for read := uint(0); read < bitWidth; {
+		byteIndex := bitPos / 8
+		bitIndex := bitPos % 8
+		n := 8 - bitIndex
+		if n > bitWidth-read {
+			n = bitWidth - read
+		}
+		value |= (uint64(src[byteIndex]>>bitIndex) & (1<<n - 1)) << read
+		read += n
+		bitPos += n
+	}
+	return value
+}
+
+// unpackBits64 unpacks count bit-packed values of up to 64 bits.
+// src must hold at least count*bitWidth bits.
+func unpackBits64(src []byte, count int, bitWidth uint) []uint64 {
+	dst := make([]uint64, count)
+	if bitWidth == 0 {
+		return dst
+	}
+
+	for i := range dst {
+		dst[i] = bitsAt(src, uint(i)*bitWidth, bitWidth)
+	}
+	return dst
+}
@@ -0,0 +1,83 @@
+package parquet
+
+import (
+	"encoding/binary"
+	"errors"
+	"io"
+	"reflect"
+	"testing"
+)
+
+// packBits bit-packs values LSB first, padding them to a multiple of 8.
+func packBits(values []uint32, bitWidth uint) []byte {
+	groups := (len(values) + 7) / 8
+	dst := make([]byte, groups*int(bitWidth))
+	for i, v := range values {
+		for b := uint(0); b < bitWidth; b++ {
+			if v&(1<<b) != 0 {
+				pos := uint(i)*bitWidth + b
+				dst[pos/8] |= 1 << (pos % 8)
+			}
+		}
+	}
+	return dst
+}
+
+func TestDecodeRLEHybrid(t *testing.T) {
+	for _, bitWidth := range []uint{1, 2, 3, 7, 8, 13, 17, 24, 31, 32} {
+		max := uint32(1<<bitWidth - 1)
+		packed := []uint32{0, max, 1, max - 1, max / 2, 0, max, 1}
+		repeated := max / 3
+
+		// A bit-packed run of one group, then an RLE run of 4 values.
+		data := append([]byte{0x03}, packBits(packed, bitWidth)...)
+		data = append(data, 0x08)
+		data = binary.LittleEndian.AppendUint32(data, repeated)[:len(data)+int(bitWidth+7)/8]
+
+		want := append(append([]uint32{}, packed...), repeated, repeated, repeated, repeated)
+		got, n, err := decodeRLEHybrid(data, len(want), bitWidth)
+		if err != nil {
+			t.Fatalf("bit width %d: %v", bitWidth, err)
+		}
+		if !reflect.DeepEqual(got, want) || n != len(data) {
+			t.Errorf("bit width %d: got %v (%d bytes), want %v (%d bytes)", bitWidth, got, n, want, len(data))
+		}
+	}
+}
+
+func TestDecodeRLEHybridStopsAtNumValues(t *testing.T) {
+	// An RLE run of 10 zeros at bit width 0, of which only 3 are wanted,
+	// followed by bytes that belong to the next section.
+	got, n, err := decodeRLEHybrid([]byte{0x14, 0xff}, 3, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := []uint32{0, 0, 0}; !reflect.DeepEqual(got, want) || n != 1 {
+		t.Errorf("got %v (%d bytes), want %v (1 byte)", got, n, want)
+	}
+}
+
+func TestDecodeRLEHybridErrors(t *testing.T) {
+	tests := []struct {
+		name     string
+		data     []byte
+		bitWidth uint
+		eof      bool
+	}{
+		{"bit width over 32", []byte{0x02, 0, 0, 0, 0, 0}, 33, false},
+		{"truncated header", []byte{0x80}, 1, true},
+		{"truncated bit-packed run", []byte{0x03, 0xff}, 2, true},
+		{"truncated RLE value", []byte{0x02, 0xff}, 17, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, _, err := decodeRLEHybrid(tt.data, 8, tt.bitWidth)
+			if err == nil {
+				t.Fatal("expected an error")
+			}
+			if tt.eof && !errors.Is(err, io.ErrUnexpectedEOF) {
+				t.Errorf("got %v, want io.ErrUnexpectedEOF", err)
+			}
+		})
+	}
+}
@@ -0,0 +1,111 @@
+package parquet
+
+import (
+	"fmt"
+	"strings"
+)
+
+// SchemaNode is one node of the schema tree. The footer stores the schema as a
+// depth-first list of SchemaElements where groups announce their child count
+// via NumChildren; buildSchemaTree turns that list back into a tree.
+type SchemaNode struct {
+	Element  SchemaElement
+	Parent   *SchemaNode
+	Children []*SchemaNode
+
+	// Path is the list of names from the root (excluded) down to this node.
+	// For leaves it matches ColumnMetaData.PathInSchema.
+	Path []string
+
+	// MaxDefinitionLevel and MaxRepetitionLevel are the highest levels a value
+	// at this node can have: every non-REQUIRED node on the path adds one
+	// definition level and every REPEATED node adds one repetition level.
+	MaxDefinitionLevel int
+	MaxRepetitionLevel int
+
+	// ColumnIndex is the position of a leaf in RowGroup.Columns, -1 for groups.
+	ColumnIndex int
+}
+
+// IsLeaf reports whether the node is a primitive column.
+func (n *SchemaNode) IsLeaf() bool {
+	return n.ColumnIndex >= 0
+}
+
+// IsRepeated reports whether the node has REPEATED repetition.
+func (n *SchemaNode) IsRepeated() bool {
+	return n.Element.RepetitionType != nil && *n.Element.RepetitionType == 2
+}
+
+// IsRequired reports whether the node has REQUIRED repetition. The root and
+// elements without a repetition type are treated as required.
+func (n *SchemaNode) IsRequired() bool {
+	return n.Element.RepetitionType == nil || *n.Element.RepetitionType == 0
+}
+
+// DottedPath returns Path joined with ".".
+func (n *SchemaNode) DottedPath() string {
+	return strings.Join(n.Path, ".")
+}
+
+// buildSchemaTree rebuilds the schema tree and returns its root and leaves,
+// the latter in column order.
+func buildSchemaTree(elements []SchemaElement) (*SchemaNode, []*SchemaNode, error) {
+	if len(elements) == 0 {
+		return nil, nil, fmt.Errorf("empty schema")
+	}
+
+	root := &SchemaNode{Element: elements[0], ColumnIndex: -1}
+	var leaves []*SchemaNode
+	pos := 1
+
+	var build func(parent *SchemaNode, numChildren int) error
+	build = func(parent *SchemaNode, numChildren int) error {
+		for i := 0; i < numChildren; i++ {
+			if pos >= len(elements) {
+				return fmt.Errorf("schema element %q declares more children than the schema has", parent.Element.Name)
+			}
+			elem := elements[pos]
+			pos++
+
+			node := &SchemaNode{
+				Element:            elem,
+				Parent:             parent,
+				Path:               append(append([]string{}, parent.Path...), elem.Name),
+				MaxDefinitionLevel: parent.MaxDefinitionLevel,
+				MaxRepetitionLevel: parent.MaxRepetitionLevel,
+				ColumnIndex:        -1,
+			}
+			if !node.IsRequired() {
+				node.MaxDefinitionLevel++
+			}
+			if node.IsRepeated() {
+				node.MaxRepetitionLevel++
+			}
+			parent.Children = append(parent.Children, node)
+
+			if elem.NumChildren != nil && *elem.NumChildren > 0 {
+				if err := build(node, int(*elem.NumChildren)); err != nil {
+					return err
+				}
+			} else {
+				node.ColumnIndex = len(leaves)
+				leaves = append(leaves, node)
+			}
+		}
+		return nil
+	}
+
+	numChildren := 0
+	if root.Element.NumChildren != nil {
+		numChildren = int(*root.Element.NumChildren)
+	}
+	if err := build(root, numChildren); err != nil {
+		return nil, nil, err
+	}
+	if pos != len(elements) {
+		return nil, nil, fmt.Errorf("schema has %d elements but the tree only covers %d", len(elements), pos)
+	}
+
+	return root, leaves, nil
+}
@@ -0,0 +1,97 @@
+package parquet
+
+import (
+	"reflect"
+	"testing"
+)
+
+// testLeaf and testGroup build schema elements. repetition is 0 (REQUIRED),
+// 1 (OPTIONAL) or 2 (REPEATED); convertedType -1 leaves it unset.
+func testLeaf(name string, repetition, dataType int32) SchemaElement {
+	return SchemaElement{Name: name, Type: dataType, RepetitionType: &repetition}
+}
+
+func testGroup(name string, repetition, convertedType, numChildren int32) SchemaElement {
+	elem := SchemaElement{Name: name, RepetitionType: &repetition, NumChildren: &numChildren}
+	if convertedType >= 0 {
+		elem.ConvertedType = &convertedType
+	}
+	return elem
+}
+
+// testSchema covers nested optional groups, a LIST, a bare repeated field and
+// a MAP.
+func testSchema(t *testing.T) (*SchemaNode, []*SchemaNode) {
+	t.Helper()
+	numChildren := int32(5)
+	root, leaves, err := buildSchemaTree([]SchemaElement{
+		{Name: "schema", NumChildren: &numChildren},
+		testLeaf("id", 0, 2),
+		testGroup("name", 1, -1, 1),
+		testLeaf("first", 1, 6),
+		testGroup("tags", 1, 3, 1), // LIST
+		testGroup("list", 2, -1, 1),
+		testLeaf("element", 1, 6),
+		testLeaf("phones", 2, 1),
+		testGroup("attrs", 1, 1, 1), // MAP
+		testGroup("key_value", 2, -1, 2),
+		testLeaf("key", 0, 6),
+		testLeaf("value", 1, 1),
+	})
+	if err != nil {
+		t.Fatalf("buildSchemaTree: %v", err)
+	}
+	return root, leaves
+}
+
+func TestBuildSchemaTree(t *testing.T) {
+	_, leaves := testSchema(t)
+
+	tests := []struct {
+		path          string
+		maxDefinition int
+		maxRepetition int
+	}{
+		{"id", 0, 0},
+		{"name.first", 2, 0},
+		{"tags.list.element", 3, 1},
+		{"phones", 1, 1},
+		{"attrs.key_value.key", 2, 1},
+		{"attrs.key_value.value", 3, 1},
+	}
+	if len(leaves) != len(tests) {
+		t.Fatalf("got %d leaves, want %d", len(leaves), len(tests))
+	}
+	for i, tt := range tests {
+		leaf := leaves[i]
+		if leaf.DottedPath() != tt.path || leaf.ColumnIndex != i {
+			t.Errorf("leaf %d: got %s at column %d, want %s", i, leaf.DottedPath(), leaf.ColumnIndex, tt.path)
+		}
+		if leaf.MaxDefinitionLevel != tt.maxDefinition || leaf.MaxRepetitionLevel != tt.maxRepetition {
+			t.Errorf("%s: got levels d=%d r=%d, want d=%d r=%d", tt.path,
+				leaf.MaxDefinitionLevel, leaf.MaxRepetitionLevel, tt.maxDefinition, tt.maxRepetition)
+		}
+	}
+	if got, want := leaves[2].Parent.Parent.Path, []string{"tags"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("tags path: got %v, want %v", got, want)
+	}
+}
+
+func TestBuildSchemaTreeErrors(t *testing.T) {
+	two, one := int32(2), int32(1)
+	tests := []struct {
+		name     string
+		elements []SchemaElement
+	}{
+		{"empty", nil},
+		{"missing children", []SchemaElement{{Name: "schema", NumChildren: &two}, testLeaf("a", 0, 1)}},
+		{"extra elements", []SchemaElement{{Name: "schema", NumChildren: &one}, testLeaf("a", 0, 1), testLeaf("b", 0, 1)}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, _, err := buildSchemaTree(tt.elements); err == nil {
+				t.Error("expected an error")
+			}
+		})
+	}
+}
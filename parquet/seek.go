@@ -0,0 +1,147 @@
+package parquet
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"sort"
+)
+
+// rowCursor is the read position within a column chunk: the decoded page
+// holding the next slot and the index of the next row to start.
+type rowCursor struct {
+	pages *pageDecoder
+	page  *columnData
+	slot  int // next slot in page
+	value int // index in page.values of the next defined value
+	row   int64
+}
+
+// SeekToRow positions the reader at row (counted from the start of the row
+// group) for ReadRows. When the chunk has an OffsetIndex, decoding starts at
+// the page containing the row, after reading only the dictionary page if
+// there is one. Without an index the chunk is scanned from its first page.
+// A failed seek resets the position to row 0. Values and the typed readers
+// always read the whole chunk.
+func (c *ColumnChunkReader) SeekToRow(row int64) error {
+	previous := c.cursor
+	c.cursor = nil
+	if row < 0 || row >= c.numRows {
+		return fmt.Errorf("row %d out of range [0, %d)", row, c.numRows)
+	}
+
+	pages, err := newPageDecoder(c.r, c.chunk, c.leaf, c.opts)
+	if err != nil {
+		return err
+	}
+	if previous != nil {
+		// The dictionary is the same for the whole chunk.
+		pages.dictionary = previous.pages.dictionary
+	}
+	cursor := &rowCursor{pages: pages}
+
+	index, err := c.OffsetIndex()
+	if err != nil {
+		return err
+	}
+	if index != nil && len(index.PageLocations) > 0 {
+		locs := index.PageLocations
+		i := sort.Search(len(locs), func(i int) bool { return locs[i].FirstRowIndex > row }) - 1
+		if i < 0 {
+			return fmt.Errorf("offset index starts at row %d", locs[0].FirstRowIndex)
+		}
+		if err := pages.seek(locs[i].Offset); err != nil {
+			return err
+		}
+		cursor.row = locs[i].FirstRowIndex
+	}
+
+	skip := int(row - cursor.row)
+	if skipped, err := cursor.read(skip, nil); err != nil {
+		return err
+	} else if skipped < skip {
+		return fmt.Errorf("column %s ends at row %d, before row %d", c.leaf.DottedPath(), cursor.row, row)
+	}
+	c.cursor = cursor
+	return nil
+}
+
+// ReadRows returns the values of the next n rows and advances the read
+// position, which starts at row 0 unless set by SeekToRow. Like Values, it
+// returns one entry per level slot, so repeated columns yield more entries
+// than rows. Fewer rows are returned at the end of the chunk, and io.EOF once
+// no rows are left.
+func (c *ColumnChunkReader) ReadRows(n int) ([]interface{}, error) {
+	if c.cursor == nil {
+		pages, err := newPageDecoder(c.r, c.chunk, c.leaf, c.opts)
+		if err != nil {
+			return nil, err
+		}
+		c.cursor = &rowCursor{pages: pages}
+	}
+
+	data := &columnData{leaf: c.leaf}
+	rows, err := c.cursor.read(n, data)
+	if err != nil {
+		return nil, err
+	}
+	if rows == 0 && n > 0 {
+		return nil, io.EOF
+	}
+	return data.slots()
+}
+
+// read consumes the next n rows, appending their values and levels to out
+// unless it is nil, and returns the number of rows consumed, which is less
+// than n at the end of the chunk. Pages are decoded only as far as needed.
+func (c *rowCursor) read(n int, out *columnData) (int, error) {
+	leaf := c.pages.leaf
+	rows := 0
+
+	for {
+		// A row of a flat column is a single slot, so it ends right away;
+		// otherwise the row ends at the next slot starting a new one.
+		if rows == n && leaf.MaxRepetitionLevel == 0 {
+			return rows, nil
+		}
+
+		if c.page == nil || c.slot == len(c.page.definitionLevels) {
+			values, repetitionLevels, definitionLevels, err := c.pages.next()
+			if errors.Is(err, io.EOF) {
+				return rows, nil
+			}
+			if err != nil {
+				return 0, err
+			}
+			c.page = &columnData{leaf: leaf}
+			c.page.appendPage(values, repetitionLevels, definitionLevels)
+			c.slot, c.value = 0, 0
+			continue
+		}
+
+		repetitionLevel := c.page.repetitionLevels[c.slot]
+		definitionLevel := c.page.definitionLevels[c.slot]
+		if repetitionLevel == 0 {
+			if rows == n {
+				return rows, nil
+			}
+			rows++
+			c.row++
+		}
+
+		if definitionLevel == uint32(leaf.MaxDefinitionLevel) {
+			if c.value >= len(c.page.values) {
+				return 0, fmt.Errorf("column %s: definition levels reference more than %d values", leaf.DottedPath(), len(c.page.values))
+			}
+			if out != nil {
+				out.values = append(out.values, c.page.values[c.value])
+			}
+			c.value++
+		}
+		if out != nil {
+			out.repetitionLevels = append(out.repetitionLevels, repetitionLevel)
+			out.definitionLevels = append(out.definitionLevels, definitionLevel)
+		}
+		c.slot++
+	}
+}
@@ -0,0 +1,318 @@
+package parquet
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"reflect"
+	"testing"
+)
+
+// seekTestRowGroup describes a chunk of a required INT32 column holding the
+// value 100+i in row i: a dictionary page followed by dictionary-encoded data
+// pages with the given row counts.
+type seekTestRowGroup struct {
+	pageRows []int
+	// corruptFirst makes the first data page reference a value outside the
+	// dictionary, so that any read of it fails.
+	corruptFirst bool
+	withIndex    bool
+}
+
+// write appends the chunk and its offset index to file and returns a reader
+// for it.
+func (g seekTestRowGroup) write(t *testing.T, file *bytes.Buffer, leaf *SchemaNode) *ColumnChunkReader {
+	t.Helper()
+	numRows := 0
+	for _, n := range g.pageRows {
+		numRows += n
+	}
+	start := int64(file.Len())
+
+	dictionary := make([]byte, 0, 4*numRows)
+	for i := 0; i < numRows; i++ {
+		v := 100 + i
+		dictionary = append(dictionary, byte(v), byte(v>>8), 0, 0)
+	}
+	var w compactWriter
+	w.begin()
+	w.i32(1, 2) // type: DICTIONARY_PAGE
+	w.i32(2, int32(len(dictionary)))
+	w.i32(3, int32(len(dictionary)))
+	w.structField(7, func() {
+		w.i32(1, int32(numRows)) // num_values
+		w.i32(2, 0)              // encoding: PLAIN
+	})
+	w.end()
+	w.Write(dictionary)
+	file.Write(w.Bytes())
+
+	var locations []PageLocation
+	row := 0
+	for p, n := range g.pageRows {
+		// Bit width 8, then a run of one for each index.
+		body := []byte{8}
+		for i := row; i < row+n; i++ {
+			index := byte(i)
+			if p == 0 && g.corruptFirst {
+				index = 0xff
+			}
+			body = append(body, 2, index)
+		}
+		w.Reset()
+		w.begin()
+		w.i32(1, 0) // type: DATA_PAGE
+		w.i32(2, int32(len(body)))
+		w.i32(3, int32(len(body)))
+		w.structField(5, func() {
+			w.i32(1, int32(n)) // num_values
+			w.i32(2, 8)        // encoding: RLE_DICTIONARY
+			w.i32(3, 3)        // definition_level_encoding: RLE
+			w.i32(4, 3)        // repetition_level_encoding: RLE
+		})
+		w.end()
+		w.Write(body)
+		locations = append(locations, PageLocation{Offset: int64(file.Len()), CompressedPageSize: int32(w.Len()), FirstRowIndex: int64(row)})
+		file.Write(w.Bytes())
+		row += n
+	}
+
+	chunk := ColumnChunk{MetaData: &ColumnMetaData{
+		Type: 1, NumValues: int64(numRows), DataPageOffset: locations[0].Offset,
+		DictionaryPageOffset: ptr(start), TotalCompressedSize: int64(file.Len()) - start,
+	}}
+	if g.withIndex {
+		w.Reset()
+		w.begin()
+		w.list(1, 12, len(locations), func() { // page_locations
+			for _, loc := range locations {
+				w.elemStruct(func() {
+					w.i64(1, loc.Offset)
+					w.i32(2, loc.CompressedPageSize)
+					w.i64(3, loc.FirstRowIndex)
+				})
+			}
+		})
+		w.end()
+		chunk.OffsetIndexOffset = ptr(int64(file.Len()))
+		chunk.OffsetIndexLength = ptr(int32(w.Len()))
+		file.Write(w.Bytes())
+	}
+	return &ColumnChunkReader{chunk: chunk, leaf: leaf, opts: defaultOptions(), numRows: int64(numRows)}
+}
+
+// seekTestFile writes the row groups one after the other and returns a
+// reader for each.
+func seekTestFile(t *testing.T, groups ...seekTestRowGroup) []*ColumnChunkReader {
+	t.Helper()
+	numChildren := int32(1)
+	_, leaves, err := buildSchemaTree([]SchemaElement{{Name: "schema", NumChildren: &numChildren}, testLeaf("v", 0, 1)})
+	if err != nil {
+		t.Fatalf("buildSchemaTree: %v", err)
+	}
+	var file bytes.Buffer
+	file.WriteString("PAR1")
+	readers := make([]*ColumnChunkReader, len(groups))
+	for i, g := range groups {
+		readers[i] = g.write(t, &file, leaves[0])
+	}
+	r := bytes.NewReader(file.Bytes())
+	for _, c := range readers {
+		c.r = r
+	}
+	return readers
+}
+
+func rowValues(first, n int) []interface{} {
+	out := make([]interface{}, n)
+	for i := range out {
+		out[i] = int32(100 + first + i)
+	}
+	return out
+}
+
+func TestSeekToRow(t *testing.T) {
+	for _, withIndex := range []bool{false, true} {
+		c := seekTestFile(t, seekTestRowGroup{pageRows: []int{4, 4, 2}, withIndex: withIndex})[0]
+		tests := []struct {
+			name     string
+			row      int64
+			read     []int // rows requested by successive ReadRows calls
+			wantRows []int // first row of each result; -1 for io.EOF
+			wantLen  []int
+		}{
+			{"first row", 0, []int{3}, []int{0}, []int{3}},
+			{"mid-page", 5, []int{2}, []int{5}, []int{2}},
+			{"across pages", 2, []int{5}, []int{2}, []int{5}},
+			{"page boundary", 4, []int{4, 1}, []int{4, 8}, []int{4, 1}},
+			{"last page", 8, []int{1, 1}, []int{8, 9}, []int{1, 1}},
+			{"last row", 9, []int{5, 1}, []int{9, -1}, []int{1, 0}},
+			{"whole chunk", 0, []int{10, 1}, []int{0, -1}, []int{10, 0}},
+		}
+		for _, tt := range tests {
+			if err := c.SeekToRow(tt.row); err != nil {
+				t.Fatalf("index %v, %s: SeekToRow(%d): %v", withIndex, tt.name, tt.row, err)
+			}
+			for i, n := range tt.read {
+				got, err := c.ReadRows(n)
+				if tt.wantRows[i] < 0 {
+					if !errors.Is(err, io.EOF) {
+						t.Errorf("index %v, %s: read %d: got %v, %v, want io.EOF", withIndex, tt.name, i, got, err)
+					}
+					continue
+				}
+				if err != nil {
+					t.Fatalf("index %v, %s: read %d: %v", withIndex, tt.name, i, err)
+				}
+				if want := rowValues(tt.wantRows[i], tt.wantLen[i]); !reflect.DeepEqual(got, want) {
+					t.Errorf("index %v, %s: read %d: got %v, want %v", withIndex, tt.name, i, got, want)
+				}
+			}
+		}
+	}
+}
+
+func TestSeekToRowOutOfRange(t *testing.T) {
+	c := seekTestFile(t, seekTestRowGroup{pageRows: []int{4, 4, 2}, withIndex: true})[0]
+	if err := c.SeekToRow(8); err != nil {
+		t.Fatalf("SeekToRow(8): %v", err)
+	}
+	for _, row := range []int64{-1, 10, 100} {
+		if err := c.SeekToRow(row); err == nil {
+			t.Errorf("SeekToRow(%d): expected an error", row)
+		}
+	}
+	// A failed seek resets the position to the first row.
+	got, err := c.ReadRows(2)
+	if err != nil {
+		t.Fatalf("ReadRows: %v", err)
+	}
+	if want := rowValues(0, 2); !reflect.DeepEqual(got, want) {
+		t.Errorf("after a failed seek: got %v, want %v", got, want)
+	}
+}
+
+func TestSeekToRowSkipsPages(t *testing.T) {
+	// The first data page cannot be decoded, so seeks past it only succeed
+	// when the offset index lets them jump over it.
+	indexed := seekTestFile(t, seekTestRowGroup{pageRows: []int{4, 4, 2}, corruptFirst: true, withIndex: true})[0]
+	if err := indexed.SeekToRow(6); err != nil {
+		t.Fatalf("with index: %v", err)
+	}
+	got, err := indexed.ReadRows(3)
+	if err != nil {
+		t.Fatalf("with index: ReadRows: %v", err)
+	}
+	if want := rowValues(6, 3); !reflect.DeepEqual(got, want) {
+		t.Errorf("with index: got %v, want %v", got, want)
+	}
+	if err := indexed.SeekToRow(1); err == nil {
+		t.Error("with index, row 1: expected an error")
+	}
+
+	scanned := seekTestFile(t, seekTestRowGroup{pageRows: []int{4, 4, 2}, corruptFirst: true})[0]
+	if err := scanned.SeekToRow(6); err == nil {
+		t.Error("without index: expected an error")
+	}
+}
+
+func TestSeekToRowRowGroups(t *testing.T) {
+	// Rows are counted from the start of each row group, and reads stop at
+	// the end of a chunk even though the next one follows it in the file.
+	readers := seekTestFile(t,
+		seekTestRowGroup{pageRows: []int{3, 3}, withIndex: true},
+		seekTestRowGroup{pageRows: []int{2, 5}, withIndex: true},
+	)
+	first, second := readers[0], readers[1]
+
+	if err := first.SeekToRow(5); err != nil {
+		t.Fatalf("first row group: %v", err)
+	}
+	got, err := first.ReadRows(10)
+	if err != nil {
+		t.Fatalf("first row group: ReadRows: %v", err)
+	}
+	if want := rowValues(5, 1); !reflect.DeepEqual(got, want) {
+		t.Errorf("first row group: got %v, want %v", got, want)
+	}
+	if _, err := first.ReadRows(1); !errors.Is(err, io.EOF) {
+		t.Errorf("first row group: got %v, want io.EOF", err)
+	}
+	if err := first.SeekToRow(6); err == nil {
+		t.Error("first row group, row 6: expected an error")
+	}
+
+	if err := second.SeekToRow(2); err != nil {
+		t.Fatalf("second row group: %v", err)
+	}
+	got, err = second.ReadRows(2)
+	if err != nil {
+		t.Fatalf("second row group: ReadRows: %v", err)
+	}
+	if want := rowValues(2, 2); !reflect.DeepEqual(got, want) {
+		t.Errorf("second row group: got %v, want %v", got, want)
+	}
+}
+
+func TestReadRowsRepeated(t *testing.T) {
+	// One page of a repeated INT32 column with the rows [1], [2 3], [], [4 5 6].
+	var levels bytes.Buffer
+	rle := func(runs ...byte) { // pairs of run length and value, bit width 1
+		levels.Write([]byte{byte(len(runs)), 0, 0, 0})
+		for i := 0; i < len(runs); i += 2 {
+			levels.Write([]byte{runs[i] << 1, runs[i+1]})
+		}
+	}
+	rle(2, 0, 1, 1, 1, 0, 1, 0, 2, 1) // repetition levels 0 0 1 0 0 1 1
+	rle(3, 1, 1, 0, 3, 1)             // definition levels 1 1 1 0 1 1 1
+	values := []byte{1, 0, 0, 0, 2, 0, 0, 0, 3, 0, 0, 0, 4, 0, 0, 0, 5, 0, 0, 0, 6, 0, 0, 0}
+	body := append(levels.Bytes(), values...)
+
+	var w compactWriter
+	w.begin()
+	w.i32(1, 0) // type: DATA_PAGE
+	w.i32(2, int32(len(body)))
+	w.i32(3, int32(len(body)))
+	w.structField(5, func() {
+		w.i32(1, 7) // num_values
+		w.i32(2, 0) // encoding: PLAIN
+		w.i32(3, 3) // definition_level_encoding: RLE
+		w.i32(4, 3) // repetition_level_encoding: RLE
+	})
+	w.end()
+	w.Write(body)
+
+	numChildren := int32(1)
+	_, leaves, err := buildSchemaTree([]SchemaElement{{Name: "schema", NumChildren: &numChildren}, testLeaf("v", 2, 1)})
+	if err != nil {
+		t.Fatalf("buildSchemaTree: %v", err)
+	}
+	c := &ColumnChunkReader{
+		r:       bytes.NewReader(w.Bytes()),
+		chunk:   ColumnChunk{MetaData: &ColumnMetaData{Type: 1, NumValues: 7, TotalCompressedSize: int64(w.Len())}},
+		leaf:    leaves[0],
+		opts:    defaultOptions(),
+		numRows: 4,
+	}
+
+	if err := c.SeekToRow(1); err != nil {
+		t.Fatalf("SeekToRow: %v", err)
+	}
+	got, err := c.ReadRows(2)
+	if err != nil {
+		t.Fatalf("ReadRows: %v", err)
+	}
+	if want := []interface{}{int32(2), int32(3), nil}; !reflect.DeepEqual(got, want) {
+		t.Errorf("rows 1-2: got %v, want %v", got, want)
+	}
+	got, err = c.ReadRows(2)
+	if err != nil {
+		t.Fatalf("ReadRows: %v", err)
+	}
+	if want := []interface{}{int32(4), int32(5), int32(6)}; !reflect.DeepEqual(got, want) {
+		t.Errorf("row 3: got %v, want %v", got, want)
+	}
+	if _, err := c.ReadRows(1); !errors.Is(err, io.EOF) {
+		t.Errorf("after the last row: got %v, want io.EOF", err)
+	}
+}
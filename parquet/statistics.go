@@ -0,0 +1,109 @@
+package parquet
+
+import (
+	"fmt"
+)
+
+// SortOrder is the order in which min/max statistics of a column are
+// computed, derived from its physical and logical type.
+type SortOrder int
+
+const (
+	// SortOrderUnknown means no order is defined (INT96, INTERVAL) and
+	// statistics must not be used for pruning.
+	SortOrderUnknown SortOrder = iota
+	// SortOrderSigned compares numerically, as signed values.
+	SortOrderSigned
+	// SortOrderUnsigned compares unsigned integers numerically and byte
+	// arrays lexicographically as unsigned bytes.
+	SortOrderUnsigned
+)
+
+// ColumnSortOrder returns the sort order of a primitive column as defined by
+// its ColumnOrder TYPE_ORDER.
+func ColumnSortOrder(elem SchemaElement) SortOrder {
+	if elem.ConvertedType != nil && *elem.ConvertedType == 21 && elem.LogicalType == nil { // INTERVAL
+		return SortOrderUnknown
+	}
+
+	lt := elem.ResolvedLogicalType()
+	switch elem.Type {
+	case 0, 4, 5: // BOOLEAN, FLOAT, DOUBLE
+		return SortOrderSigned
+	case 1, 2: // INT32, INT64
+		if lt != nil && lt.Kind == LogicalTypeInteger && lt.Integer != nil && !lt.Integer.IsSigned {
+			return SortOrderUnsigned
+		}
+		return SortOrderSigned
+	case 3: // INT96
+		return SortOrderUnknown
+	case 6, 7: // BYTE_ARRAY, FIXED_LEN_BYTE_ARRAY
+		if lt != nil && (lt.Kind == LogicalTypeDecimal || lt.Kind == LogicalTypeFloat16) {
+			return SortOrderSigned
+		}
+		return SortOrderUnsigned
+	default:
+		return SortOrderUnknown
+	}
+}
+
+// Bounds returns the lower and upper bound recorded in s for a column with
+// the given schema element and ColumnOrder (nil when the footer has no column
+// orders). The values are decoded like ColumnChunkReader.Values; use
+// ConvertValue for their logical representation.
+//
+// min_value/max_value are only trusted when the column order is TYPE_ORDER
+// and the sort order is known; without column orders their ordering is
+// undefined. The deprecated min/max fields were computed with signed
+// comparison and are used instead only for columns whose sort order is signed
+// and which are not byte arrays, where older writers compared bytes as
+// signed. ok is false when no usable bounds exist. Floating-point bounds are
+// returned as stored, NaN and signed zeros included.
+func (s *Statistics) Bounds(elem SchemaElement, order *ColumnOrder) (min, max interface{}, ok bool, err error) {
+	sortOrder := ColumnSortOrder(elem)
+	if sortOrder == SortOrderUnknown || (order != nil && !order.TypeDefinedOrder) {
+		return nil, nil, false, nil
+	}
+
+	rawMin, rawMax := s.MinValue, s.MaxValue
+	if order == nil || rawMin == nil || rawMax == nil {
+		legacyUsable := sortOrder == SortOrderSigned && elem.Type != 6 && elem.Type != 7
+		if !legacyUsable || s.Min == nil || s.Max == nil {
+			return nil, nil, false, nil
+		}
+		rawMin, rawMax = s.Min, s.Max
+	}
+
+	if min, err = decodeStatValue(rawMin, elem); err != nil {
+		return nil, nil, false, fmt.Errorf("decoding min: %v", err)
+	}
+	if max, err = decodeStatValue(rawMax, elem); err != nil {
+		return nil, nil, false, fmt.Errorf("decoding max: %v", err)
+	}
+	return min, max, true, nil
+}
+
+// decodeStatValue decodes a single statistics value. Values are PLAIN encoded,
+// except that BYTE_ARRAY values have no length prefix.
+func decodeStatValue(raw []byte, elem SchemaElement) (interface{}, error) {
+	if elem.Type == 6 { // BYTE_ARRAY
+		return string(raw), nil
+	}
+
+	typeLength := 0
+	if elem.TypeLength != nil {
+		typeLength = int(*elem.TypeLength)
+	}
+	if elem.Type == 7 && len(raw) != typeLength { // FIXED_LEN_BYTE_ARRAY
+		return nil, fmt.Errorf("value has %d bytes, type_length is %d", len(raw), typeLength)
+	}
+
+	values, err := decodePlainValues(raw, elem.Type, typeLength, 1)
+	if err != nil {
+		return nil, err
+	}
+	if len(values) != 1 {
+		return nil, fmt.Errorf("got %d values from %d bytes", len(values), len(raw))
+	}
+	return values[0], nil
+}
@@ -0,0 +1,144 @@
+package parquet
+
+import (
+	"fmt"
+	"math"
+	"reflect"
+	"testing"
+)
+
+func TestDecodeStatValue(t *testing.T) {
+	var int96 Int96
+	int96[0], int96[11] = 1, 2
+
+	tests := []struct {
+		name string
+		elem SchemaElement
+		raw  string
+		want interface{}
+		str  string // fmt.Sprint of the value after ConvertValue
+	}{
+		{"boolean", SchemaElement{Type: 0}, "\x01", true, "true"},
+		{"int32", SchemaElement{Type: 1}, "\xfe\xff\xff\xff", int32(-2), "-2"},
+		{"int64", SchemaElement{Type: 2}, "\x00\x01\x00\x00\x00\x00\x00\x00", int64(256), "256"},
+		{"int96", SchemaElement{Type: 3}, string(int96[:]), int96, ""},
+		{"float", SchemaElement{Type: 4}, "\x00\x00\xc0\x3f", float32(1.5), "1.5"},
+		{"double", SchemaElement{Type: 5}, "\x00\x00\x00\x00\x00\x00\xf0\xbf", float64(-1), "-1"},
+		{"byte array without length prefix", SchemaElement{Type: 6}, "abc", "abc", "abc"},
+		{"empty byte array", SchemaElement{Type: 6}, "", "", ""},
+		{"fixed", SchemaElement{Type: 7, TypeLength: ptr(int32(2))}, "ab", "ab", "ab"},
+		{"uint32", SchemaElement{Type: 1, ConvertedType: ptr(int32(13))}, "\xff\xff\xff\xff", int32(-1), "4294967295"},
+		{"decimal int32", SchemaElement{Type: 1, ConvertedType: ptr(int32(5)), Scale: ptr(int32(2)), Precision: ptr(int32(5))},
+			"\x39\x30\x00\x00", int32(12345), "123.45"},
+		{"decimal fixed", SchemaElement{Type: 7, TypeLength: ptr(int32(2)), LogicalType: &LogicalType{Kind: LogicalTypeDecimal, Decimal: &DecimalType{Scale: 1, Precision: 4}}},
+			"\xff\x85", "\xff\x85", "-12.3"},
+		{"date", SchemaElement{Type: 1, LogicalType: &LogicalType{Kind: LogicalTypeDate}}, "\x56\x47\x00\x00", int32(18262), "2020-01-01"},
+		{"timestamp", SchemaElement{Type: 2, ConvertedType: ptr(int32(9))}, "\x00\x00\x00\x00\x00\x00\x00\x00", int64(0), "1970-01-01 00:00:00 +0000 UTC"},
+		{"float16", SchemaElement{Type: 7, TypeLength: ptr(int32(2)), LogicalType: &LogicalType{Kind: LogicalTypeFloat16}}, "\x00\x3c", "\x00\x3c", "1"},
+		{"string", SchemaElement{Type: 6, ConvertedType: ptr(int32(0))}, "héllo", "héllo", "héllo"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := decodeStatValue([]byte(tt.raw), tt.elem)
+			if err != nil {
+				t.Fatalf("decodeStatValue: %v", err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Fatalf("got %#v, want %#v", got, tt.want)
+			}
+			converted, err := ConvertValue(tt.elem, got)
+			if err != nil {
+				t.Fatalf("ConvertValue: %v", err)
+			}
+			if s := fmt.Sprint(converted); tt.str != "" && s != tt.str {
+				t.Errorf("converted to %q, want %q", s, tt.str)
+			}
+		})
+	}
+}
+
+func TestDecodeStatValueErrors(t *testing.T) {
+	tests := []struct {
+		name string
+		elem SchemaElement
+		raw  string
+	}{
+		{"short int32", SchemaElement{Type: 1}, "\x01\x02\x03"},
+		{"short double", SchemaElement{Type: 5}, "\x00"},
+		{"fixed wrong length", SchemaElement{Type: 7, TypeLength: ptr(int32(4))}, "ab"},
+		{"fixed without type_length", SchemaElement{Type: 7}, "ab"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got, err := decodeStatValue([]byte(tt.raw), tt.elem); err == nil {
+				t.Errorf("got %#v, expected an error", got)
+			}
+		})
+	}
+}
+
+func TestStatisticsBounds(t *testing.T) {
+	int32Elem := SchemaElement{Type: 1}
+	typeOrder := &ColumnOrder{TypeDefinedOrder: true}
+	one, two := []byte("\x01\x00\x00\x00"), []byte("\x02\x00\x00\x00")
+	nan := []byte("\x00\x00\xc0\x7f")
+
+	tests := []struct {
+		name             string
+		elem             SchemaElement
+		order            *ColumnOrder
+		stats            Statistics
+		wantMin, wantMax interface{}
+		wantOK           bool
+	}{
+		{"min_value and max_value", int32Elem, typeOrder, Statistics{MinValue: one, MaxValue: two}, int32(1), int32(2), true},
+		{"preferred over deprecated", int32Elem, typeOrder, Statistics{MinValue: one, MaxValue: two, Min: two, Max: two}, int32(1), int32(2), true},
+		{"deprecated fallback", int32Elem, typeOrder, Statistics{Min: one, Max: two}, int32(1), int32(2), true},
+		{"no column order ignores min_value", int32Elem, nil, Statistics{MinValue: one, MaxValue: two}, nil, nil, false},
+		{"no column order uses deprecated", int32Elem, nil, Statistics{MinValue: two, MaxValue: two, Min: one, Max: two}, int32(1), int32(2), true},
+		{"unknown column order", int32Elem, &ColumnOrder{}, Statistics{MinValue: one, MaxValue: two}, nil, nil, false},
+		{"only min", int32Elem, typeOrder, Statistics{MinValue: one}, nil, nil, false},
+		{"deprecated unsigned", SchemaElement{Type: 1, ConvertedType: ptr(int32(13))}, nil, Statistics{Min: one, Max: two}, nil, nil, false},
+		{"deprecated byte array", SchemaElement{Type: 6}, nil, Statistics{Min: []byte("a"), Max: []byte("b")}, nil, nil, false},
+		{"deprecated decimal byte array", SchemaElement{Type: 6, ConvertedType: ptr(int32(5)), Scale: ptr(int32(0)), Precision: ptr(int32(3))},
+			nil, Statistics{Min: []byte("\x01"), Max: []byte("\x02")}, nil, nil, false},
+		{"byte array", SchemaElement{Type: 6}, typeOrder, Statistics{MinValue: []byte("a"), MaxValue: []byte("b")}, "a", "b", true},
+		{"int96", SchemaElement{Type: 3}, typeOrder, Statistics{MinValue: make([]byte, 12), MaxValue: make([]byte, 12)}, nil, nil, false},
+		{"interval", SchemaElement{Type: 7, TypeLength: ptr(int32(12)), ConvertedType: ptr(int32(21))}, typeOrder,
+			Statistics{MinValue: make([]byte, 12), MaxValue: make([]byte, 12)}, nil, nil, false},
+		{"negative zero as stored", SchemaElement{Type: 4}, typeOrder, Statistics{MinValue: []byte("\x00\x00\x00\x80"), MaxValue: []byte("\x00\x00\x00\x00")},
+			float32(math.Copysign(0, -1)), float32(0), true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			min, max, ok, err := tt.stats.Bounds(tt.elem, tt.order)
+			if err != nil {
+				t.Fatalf("Bounds: %v", err)
+			}
+			if ok != tt.wantOK || !reflect.DeepEqual(min, tt.wantMin) || !reflect.DeepEqual(max, tt.wantMax) {
+				t.Errorf("got %#v, %#v, %v; want %#v, %#v, %v", min, max, ok, tt.wantMin, tt.wantMax, tt.wantOK)
+			}
+			if f, isFloat := min.(float32); isFloat && math.Signbit(float64(f)) != math.Signbit(float64(tt.wantMin.(float32))) {
+				t.Errorf("min sign changed: got %v", f)
+			}
+		})
+	}
+
+	t.Run("NaN as stored", func(t *testing.T) {
+		stats := Statistics{MinValue: nan, MaxValue: one}
+		min, _, ok, err := stats.Bounds(SchemaElement{Type: 4}, typeOrder)
+		if err != nil || !ok {
+			t.Fatalf("got ok %v, err %v", ok, err)
+		}
+		if f := min.(float32); f == f {
+			t.Errorf("min = %v, want NaN", f)
+		}
+	})
+
+	t.Run("undecodable", func(t *testing.T) {
+		stats := Statistics{MinValue: []byte("\x01"), MaxValue: two}
+		if _, _, _, err := stats.Bounds(int32Elem, typeOrder); err == nil {
+			t.Error("expected an error")
+		}
+	})
+}
@@ -0,0 +1,1622 @@
+package parquet
+
+import (
+	"bufio"
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/kaitai-io/kaitai_struct_go_runtime/kaitai"
+	"kaitai_parquet/kaitai_gen"
+)
+
+type thriftField struct {
+	ID   int16
+	Type uint8
+	Val  *kaitai_gen.ThriftCompact_CompactValue
+}
+
+func parseCompactStructFromBytes(b []byte) (*kaitai_gen.ThriftCompact_CompactStruct, int, error) {
+	br := bytes.NewReader(b)
+	ks := kaitai.NewStream(br)
+
+	// Parquet stores raw Thrift structs, not message envelopes.
+	root := kaitai_gen.NewThriftCompact()
+	st := kaitai_gen.NewThriftCompact_CompactStruct()
+	if err := st.Read(ks, nil, root); err != nil {
+		return nil, 0, err
+	}
+
+	pos, _ := br.Seek(0, io.SeekCurrent)
+	return st, int(pos), nil
+}
+
+func parseCompactStructFromBufio(r *bufio.Reader, maxPeek int) (*kaitai_gen.ThriftCompact_CompactStruct, int, error) {
+	if maxPeek <= 0 {
+		maxPeek = 64 * 1024
+	}
+
+	peekN := 256
+	for {
+		if peekN > maxPeek {
+			peekN = maxPeek
+		}
+
+		b, err := r.Peek(peekN)
+		// We can still attempt to parse on io.EOF (Peek returns available bytes).
+		if err != nil && !errors.Is(err, io.EOF) {
+			return nil, 0, err
+		}
+		if len(b) == 0 {
+			return nil, 0, io.EOF
+		}
+
+		st, consumed, perr := parseCompactStructFromBytes(b)
+		if perr == nil {
+			if consumed <= 0 {
+				return nil, 0, fmt.Errorf("thrift compact parse: consumed=0")
+			}
+			if _, derr := r.Discard(consumed); derr != nil {
+				return nil, 0, derr
+			}
+			return st, consumed, nil
+		}
+
+		// If parsing failed due to insufficient bytes, increase peek and retry.
+		if errors.Is(perr, io.EOF) || strings.Contains(perr.Error(), "EOF") {
+			if peekN >= maxPeek {
+				return nil, 0, perr
+			}
+			peekN *= 2
+			continue
+		}
+		return nil, 0, perr
+	}
+}
+
+func thriftFields(st *kaitai_gen.ThriftCompact_CompactStruct) ([]thriftField, error) {
+	out := make([]thriftField, 0, len(st.Fields))
+	var prevID int16
+
+	for _, f := range st.Fields {
+		isStop, err := f.IsStop()
+		if err != nil {
+			return nil, err
+		}
+		if isStop {
+			break
+		}
+
+		ft, err := f.FieldType()
+		if err != nil {
+			return nil, err
+		}
+
+		hasExt, err := f.HasExtendedDelta()
+		if err != nil {
+			return nil, err
+		}
+
+		var id int16
+		if hasExt {
+			if f.ExtendedDelta == nil {
+				return nil, fmt.Errorf("thrift compact: extended delta missing")
+			}
+			v, err := f.ExtendedDelta.Value()
+			if err != nil {
+				return nil, err
+			}
+			id = int16(v)
+		} else {
+			delta, err := f.FieldDeltaShort()
+			if err != nil {
+				return nil, err
+			}
+			id = prevID + int16(delta)
+		}
+
+		prevID = id
+		out = append(out, thriftField{
+			ID:   id,
+			Type: uint8(ft),
+			Val:  f.Value,
+		})
+	}
+
+	return out, nil
+}
+
+func thriftI32(v *kaitai_gen.ThriftCompact_CompactValue) (int32, bool, error) {
+	if v == nil || v.I32Value == nil {
+		return 0, false, nil
+	}
+	x, err := v.I32Value.Value()
+	return int32(x), true, err
+}
+
+func thriftI64(v *kaitai_gen.ThriftCompact_CompactValue) (int64, bool, error) {
+	if v == nil || v.I64Value == nil {
+		return 0, false, nil
+	}
+	x, err := v.I64Value.Value()
+	return int64(x), true, err
+}
+
+// thriftBool reads a bool struct field. The compact protocol stores the value
+// in the field type nibble (1 = true, 2 = false) rather than in the payload.
+func thriftBool(f thriftField) (bool, bool) {
+	switch f.Type {
+	case 1:
+		return true, true
+	case 2:
+		return false, true
+	default:
+		return false, false
+	}
+}
+
+// thriftI8 reads a byte (i8) field. The generated ByteValue has no presence
+// marker, so the field type decides whether it was read.
+func thriftI8(f thriftField) (int8, bool) {
+	if f.Type != 3 || f.Val == nil {
+		return 0, false
+	}
+	return f.Val.ByteValue, true
+}
+
+// thriftDouble reads a double field. Like ByteValue, DoubleValue has no
+// presence marker.
+func thriftDouble(f thriftField) (float64, bool) {
+	if f.Type != 7 || f.Val == nil {
+		return 0, false
+	}
+	return f.Val.DoubleValue, true
+}
+
+func thriftString(v *kaitai_gen.ThriftCompact_CompactValue) (string, bool) {
+	if v == nil || v.BinaryValue == nil {
+		return "", false
+	}
+	return v.BinaryValue.Value, true
+}
+
+func thriftStruct(v *kaitai_gen.ThriftCompact_CompactValue) (*kaitai_gen.ThriftCompact_CompactStruct, bool) {
+	if v == nil || v.StructValue == nil {
+		return nil, false
+	}
+	return v.StructValue, true
+}
+
+func thriftList(v *kaitai_gen.ThriftCompact_CompactValue) (*kaitai_gen.ThriftCompact_CompactList, bool) {
+	if v == nil {
+		return nil, false
+	}
+	if v.ListValue != nil {
+		return v.ListValue, true
+	}
+	if v.SetValue != nil {
+		return v.SetValue, true
+	}
+	return nil, false
+}
+
+// decodeFileMetaData converts the Thrift Compact AST for Parquet FileMetaData into FileMetadata.
+func decodeFileMetaData(st *kaitai_gen.ThriftCompact_CompactStruct) (*FileMetadata, error) {
+	fields, err := thriftFields(st)
+	if err != nil {
+		return nil, err
+	}
+
+	meta := &FileMetadata{}
+
+	for _, f := range fields {
+		switch f.ID {
+		case 1: // version: i32
+			if v, ok, err := thriftI32(f.Val); err == nil && ok {
+				meta.Version = v
+			} else if err != nil {
+				return nil, err
+			}
+		case 2: // schema: list<SchemaElement>
+			lst, ok := thriftList(f.Val)
+			if !ok || lst == nil {
+				continue
+			}
+			for _, elem := range lst.Elements {
+				sst, ok := thriftStruct(elem)
+				if !ok {
+					continue
+				}
+				se, err := decodeSchemaElement(sst)
+				if err != nil {
+					return nil, err
+				}
+				meta.Schema = append(meta.Schema, se)
+			}
+		case 3: // num_rows: i64
+			if v, ok, err := thriftI64(f.Val); err == nil && ok {
+				meta.NumRows = v
+			} else if err != nil {
+				return nil, err
+			}
+		case 4: // row_groups: list<RowGroup>
+			lst, ok := thriftList(f.Val)
+			if !ok || lst == nil {
+				continue
+			}
+			for _, elem := range lst.Elements {
+				rst, ok := thriftStruct(elem)
+				if !ok {
+					continue
+				}
+				rg, err := decodeRowGroup(rst)
+				if err != nil {
+					return nil, err
+				}
+				meta.RowGroups = append(meta.RowGroups, rg)
+			}
+		case 5: // key_value_metadata: list<KeyValue> (optional)
+			lst, ok := thriftList(f.Val)
+			if !ok || lst == nil {
+				continue
+			}
+			for _, elem := range lst.Elements {
+				kst, ok := thriftStruct(elem)
+				if !ok {
+					continue
+				}
+				kv, err := decodeKeyValue(kst)
+				if err != nil {
+					return nil, err
+				}
+				meta.KeyValueMetadata = append(meta.KeyValueMetadata, kv)
+			}
+		case 6: // created_by: string (optional)
+			if s, ok := thriftString(f.Val); ok {
+				meta.CreatedBy = &s
+			}
+		case 7: // column_orders: list<ColumnOrder> (optional)
+			lst, ok := thriftList(f.Val)
+			if !ok || lst == nil {
+				continue
+			}
+			for _, elem := range lst.Elements {
+				ost, ok := thriftStruct(elem)
+				if !ok {
+					continue
+				}
+				order, err := decodeColumnOrder(ost)
+				if err != nil {
+					return nil, err
+				}
+				meta.ColumnOrders = append(meta.ColumnOrders, order)
+			}
+		case 8: // encryption_algorithm: EncryptionAlgorithm (optional)
+			if est, ok := thriftStruct(f.Val); ok {
+				alg, err := decodeEncryptionAlgorithm(est)
+				if err != nil {
+					return nil, err
+				}
+				meta.EncryptionAlgorithm = alg
+			}
+		case 9: // footer_signing_key_metadata: binary (optional)
+			if s, ok := thriftString(f.Val); ok {
+				meta.FooterSigningKeyMetadata = []byte(s)
+			}
+		default:
+			// ignore
+		}
+	}
+
+	return meta, nil
+}
+
+func decodeKeyValue(st *kaitai_gen.ThriftCompact_CompactStruct) (KeyValue, error) {
+	fields, err := thriftFields(st)
+	if err != nil {
+		return KeyValue{}, err
+	}
+
+	var out KeyValue
+	for _, f := range fields {
+		switch f.ID {
+		case 1: // key: string
+			if s, ok := thriftString(f.Val); ok {
+				out.Key = s
+			}
+		case 2: // value: string (optional)
+			if s, ok := thriftString(f.Val); ok {
+				out.Value = &s
+			}
+		default:
+			// ignore
+		}
+	}
+
+	return out, nil
+}
+
+func decodeColumnOrder(st *kaitai_gen.ThriftCompact_CompactStruct) (ColumnOrder, error) {
+	fields, err := thriftFields(st)
+	if err != nil {
+		return ColumnOrder{}, err
+	}
+
+	var out ColumnOrder
+	for _, f := range fields {
+		switch f.ID {
+		case 1: // TYPE_ORDER: TypeDefinedOrder (empty struct)
+			out.TypeDefinedOrder = true
+		default:
+			// ignore
+		}
+	}
+
+	return out, nil
+}
+
+func decodeEncryptionAlgorithm(st *kaitai_gen.ThriftCompact_CompactStruct) (*EncryptionAlgorithm, error) {
+	fields, err := thriftFields(st)
+	if err != nil {
+		return nil, err
+	}
+
+	out := &EncryptionAlgorithm{}
+	for _, f := range fields {
+		sst, ok := thriftStruct(f.Val)
+		if !ok {
+			continue
+		}
+		switch f.ID {
+		case 1: // AES_GCM_V1: AesGcmV1
+			alg, err := decodeAesGcm(sst)
+			if err != nil {
+				return nil, err
+			}
+			out.AesGcmV1 = alg
+		case 2: // AES_GCM_CTR_V1: AesGcmCtrV1
+			alg, err := decodeAesGcm(sst)
+			if err != nil {
+				return nil, err
+			}
+			out.AesGcmCtrV1 = alg
+		default:
+			// ignore
+		}
+	}
+
+	return out, nil
+}
+
+// decodeAesGcm decodes an AesGcmV1 or AesGcmCtrV1; both have the same fields.
+func decodeAesGcm(st *kaitai_gen.ThriftCompact_CompactStruct) (*AesGcm, error) {
+	fields, err := thriftFields(st)
+	if err != nil {
+		return nil, err
+	}
+
+	out := &AesGcm{}
+	for _, f := range fields {
+		switch f.ID {
+		case 1: // aad_prefix: binary (optional)
+			if s, ok := thriftString(f.Val); ok {
+				out.AADPrefix = []byte(s)
+			}
+		case 2: // aad_file_unique: binary (optional)
+			if s, ok := thriftString(f.Val); ok {
+				out.AADFileUnique = []byte(s)
+			}
+		case 3: // supply_aad_prefix: bool (optional)
+			if v, ok := thriftBool(f); ok {
+				out.SupplyAADPrefix = &v
+			}
+		default:
+			// ignore
+		}
+	}
+
+	return out, nil
+}
+
+func decodeSchemaElement(st *kaitai_gen.ThriftCompact_CompactStruct) (SchemaElement, error) {
+	fields, err := thriftFields(st)
+	if err != nil {
+		return SchemaElement{}, err
+	}
+
+	var out SchemaElement
+	for _, f := range fields {
+		switch f.ID {
+		case 1: // type (enum): i32
+			if v, ok, err := thriftI32(f.Val); err == nil && ok {
+				out.Type = v
+			} else if err != nil {
+				return SchemaElement{}, err
+			}
+		case 2: // type_length: i32 (optional)
+			if v, ok, err := thriftI32(f.Val); err == nil && ok {
+				out.TypeLength = &v
+			} else if err != nil {
+				return SchemaElement{}, err
+			}
+		case 3: // repetition_type (enum): i32 (optional)
+			if v, ok, err := thriftI32(f.Val); err == nil && ok {
+				out.RepetitionType = &v
+			} else if err != nil {
+				return SchemaElement{}, err
+			}
+		case 4: // name: string
+			if s, ok := thriftString(f.Val); ok {
+				out.Name = s
+			}
+		case 5: // num_children: i32 (optional)
+			if v, ok, err := thriftI32(f.Val); err == nil && ok {
+				out.NumChildren = &v
+			} else if err != nil {
+				return SchemaElement{}, err
+			}
+		case 6: // converted_type: i32 (optional)
+			if v, ok, err := thriftI32(f.Val); err == nil && ok {
+				out.ConvertedType = &v
+			} else if err != nil {
+				return SchemaElement{}, err
+			}
+		case 7: // scale: i32 (optional)
+			if v, ok, err := thriftI32(f.Val); err == nil && ok {
+				out.Scale = &v
+			} else if err != nil {
+				return SchemaElement{}, err
+			}
+		case 8: // precision: i32 (optional)
+			if v, ok, err := thriftI32(f.Val); err == nil && ok {
+				out.Precision = &v
+			} else if err != nil {
+				return SchemaElement{}, err
+			}
+		case 9: // field_id: i32 (optional)
+			if v, ok, err := thriftI32(f.Val); err == nil && ok {
+				out.FieldID = &v
+			} else if err != nil {
+				return SchemaElement{}, err
+			}
+		case 10: // logicalType: LogicalType (optional)
+			if sst, ok := thriftStruct(f.Val); ok {
+				lt, err := decodeLogicalType(sst)
+				if err != nil {
+					return SchemaElement{}, err
+				}
+				out.LogicalType = lt
+			}
+		default:
+			// ignore
+		}
+	}
+
+	return out, nil
+}
+
+// decodeLogicalType decodes the LogicalType union. A member unknown to this
+// reader yields nil, so that callers fall back to the ConvertedType.
+func decodeLogicalType(st *kaitai_gen.ThriftCompact_CompactStruct) (*LogicalType, error) {
+	fields, err := thriftFields(st)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, f := range fields {
+		sst, ok := thriftStruct(f.Val)
+		if !ok {
+			continue
+		}
+		out := &LogicalType{Kind: LogicalTypeKind(f.ID)}
+		switch f.ID {
+		case 1, 2, 3, 4, 6, 11, 12, 13, 14, 15: // members without parameters
+		case 5: // DECIMAL: DecimalType
+			dt, err := decodeDecimalType(sst)
+			if err != nil {
+				return nil, err
+			}
+			out.Decimal = dt
+		case 7: // TIME: TimeType
+			adjusted, unit, err := decodeTemporalType(sst)
+			if err != nil {
+				return nil, err
+			}
+			out.Time = &TimeType{IsAdjustedToUTC: adjusted, Unit: unit}
+		case 8: // TIMESTAMP: TimestampType
+			adjusted, unit, err := decodeTemporalType(sst)
+			if err != nil {
+				return nil, err
+			}
+			out.Timestamp = &TimestampType{IsAdjustedToUTC: adjusted, Unit: unit}
+		case 10: // INTEGER: IntType
+			it, err := decodeIntType(sst)
+			if err != nil {
+				return nil, err
+			}
+			out.Integer = it
+		case 16: // VARIANT: VariantType
+			vt, err := decodeVariantType(sst)
+			if err != nil {
+				return nil, err
+			}
+			out.Variant = vt
+		case 17: // GEOMETRY: GeometryType
+			gt, err := decodeGeometryType(sst)
+			if err != nil {
+				return nil, err
+			}
+			out.Geometry = gt
+		case 18: // GEOGRAPHY: GeographyType
+			gt, err := decodeGeographyType(sst)
+			if err != nil {
+				return nil, err
+			}
+			out.Geography = gt
+		default:
+			continue
+		}
+		return out, nil
+	}
+
+	return nil, nil
+}
+
+func decodeDecimalType(st *kaitai_gen.ThriftCompact_CompactStruct) (*DecimalType, error) {
+	fields, err := thriftFields(st)
+	if err != nil {
+		return nil, err
+	}
+
+	out := &DecimalType{}
+	for _, f := range fields {
+		switch f.ID {
+		case 1: // scale: i32
+			if v, ok, err := thriftI32(f.Val); err == nil && ok {
+				out.Scale = v
+			} else if err != nil {
+				return nil, err
+			}
+		case 2: // precision: i32
+			if v, ok, err := thriftI32(f.Val); err == nil && ok {
+				out.Precision = v
+			} else if err != nil {
+				return nil, err
+			}
+		default:
+			// ignore
+		}
+	}
+
+	return out, nil
+}
+
+// decodeTemporalType decodes TimeType and TimestampType, which share their
+// layout: 1: isAdjustedToUTC, 2: unit.
+func decodeTemporalType(st *kaitai_gen.ThriftCompact_CompactStruct) (bool, TimeUnit, error) {
+	fields, err := thriftFields(st)
+	if err != nil {
+		return false, 0, err
+	}
+
+	var adjusted bool
+	var unit TimeUnit
+	for _, f := range fields {
+		switch f.ID {
+		case 1: // isAdjustedToUTC: bool
+			if v, ok := thriftBool(f); ok {
+				adjusted = v
+			}
+		case 2: // unit: TimeUnit (union of empty structs)
+			ust, ok := thriftStruct(f.Val)
+			if !ok {
+				continue
+			}
+			unitFields, err := thriftFields(ust)
+			if err != nil {
+				return false, 0, err
+			}
+			if len(unitFields) > 0 {
+				unit = TimeUnit(unitFields[0].ID)
+			}
+		default:
+			// ignore
+		}
+	}
+
+	return adjusted, unit, nil
+}
+
+func decodeIntType(st *kaitai_gen.ThriftCompact_CompactStruct) (*IntType, error) {
+	fields, err := thriftFields(st)
+	if err != nil {
+		return nil, err
+	}
+
+	out := &IntType{}
+	for _, f := range fields {
+		switch f.ID {
+		case 1: // bitWidth: i8
+			if v, ok := thriftI8(f); ok {
+				out.BitWidth = v
+			}
+		case 2: // isSigned: bool
+			if v, ok := thriftBool(f); ok {
+				out.IsSigned = v
+			}
+		default:
+			// ignore
+		}
+	}
+
+	return out, nil
+}
+
+func decodeVariantType(st *kaitai_gen.ThriftCompact_CompactStruct) (*VariantType, error) {
+	fields, err := thriftFields(st)
+	if err != nil {
+		return nil, err
+	}
+
+	out := &VariantType{}
+	for _, f := range fields {
+		switch f.ID {
+		case 1: // specification_version: i8 (optional)
+			if v, ok := thriftI8(f); ok {
+				out.SpecificationVersion = &v
+			}
+		default:
+			// ignore
+		}
+	}
+
+	return out, nil
+}
+
+func decodeGeometryType(st *kaitai_gen.ThriftCompact_CompactStruct) (*GeometryType, error) {
+	fields, err := thriftFields(st)
+	if err != nil {
+		return nil, err
+	}
+
+	out := &GeometryType{}
+	for _, f := range fields {
+		switch f.ID {
+		case 1: // crs: string (optional)
+			if s, ok := thriftString(f.Val); ok {
+				out.CRS = &s
+			}
+		default:
+			// ignore
+		}
+	}
+
+	return out, nil
+}
+
+func decodeGeographyType(st *kaitai_gen.ThriftCompact_CompactStruct) (*GeographyType, error) {
+	fields, err := thriftFields(st)
+	if err != nil {
+		return nil, err
+	}
+
+	out := &GeographyType{}
+	for _, f := range fields {
+		switch f.ID {
+		case 1: // crs: string (optional)
+			if s, ok := thriftString(f.Val); ok {
+				out.CRS = &s
+			}
+		case 2: // algorithm: EdgeInterpolationAlgorithm (optional)
+			if v, ok, err := thriftI32(f.Val); err == nil && ok {
+				out.Algorithm = &v
+			} else if err != nil {
+				return nil, err
+			}
+		default:
+			// ignore
+		}
+	}
+
+	return out, nil
+}
+
+func decodeRowGroup(st *kaitai_gen.ThriftCompact_CompactStruct) (RowGroup, error) {
+	fields, err := thriftFields(st)
+	if err != nil {
+		return RowGroup{}, err
+	}
+
+	var out RowGroup
+	for _, f := range fields {
+		switch f.ID {
+		case 1: // columns: list<ColumnChunk>
+			lst, ok := thriftList(f.Val)
+			if !ok || lst == nil {
+				continue
+			}
+			for _, elem := range lst.Elements {
+				cst, ok := thriftStruct(elem)
+				if !ok {
+					continue
+				}
+				cc, err := decodeColumnChunk(cst)
+				if err != nil {
+					return RowGroup{}, err
+				}
+				out.Columns = append(out.Columns, cc)
+			}
+		case 2: // total_byte_size: i64
+			if v, ok, err := thriftI64(f.Val); err == nil && ok {
+				out.TotalByteSize = v
+			} else if err != nil {
+				return RowGroup{}, err
+			}
+		case 3: // num_rows: i64
+			if v, ok, err := thriftI64(f.Val); err == nil && ok {
+				out.NumRows = v
+			} else if err != nil {
+				return RowGroup{}, err
+			}
+		case 5: // file_offset: i64 (optional)
+			if v, ok, err := thriftI64(f.Val); err == nil && ok {
+				out.FileOffset = v
+			} else if err != nil {
+				return RowGroup{}, err
+			}
+		case 6: // total_compressed_size: i64 (optional)
+			if v, ok, err := thriftI64(f.Val); err == nil && ok {
+				out.TotalCompressedSize = v
+			} else if err != nil {
+				return RowGroup{}, err
+			}
+		default:
+			// ignore
+		}
+	}
+
+	return out, nil
+}
+
+func decodeColumnChunk(st *kaitai_gen.ThriftCompact_CompactStruct) (ColumnChunk, error) {
+	fields, err := thriftFields(st)
+	if err != nil {
+		return ColumnChunk{}, err
+	}
+
+	var out ColumnChunk
+	for _, f := range fields {
+		switch f.ID {
+		case 1: // file_path: string (optional)
+			if s, ok := thriftString(f.Val); ok && s != "" {
+				out.FilePath = []string{s}
+			}
+		case 2: // file_offset: i64
+			if v, ok, err := thriftI64(f.Val); err == nil && ok {
+				out.FileOffset = v
+			} else if err != nil {
+				return ColumnChunk{}, err
+			}
+		case 3: // meta_data: ColumnMetaData
+			if sst, ok := thriftStruct(f.Val); ok {
+				md, err := decodeColumnMetaData(sst)
+				if err != nil {
+					return ColumnChunk{}, err
+				}
+				out.MetaData = md
+			}
+		case 4: // offset_index_offset: i64 (optional)
+			if v, ok, err := thriftI64(f.Val); err == nil && ok {
+				out.OffsetIndexOffset = &v
+			} else if err != nil {
+				return ColumnChunk{}, err
+			}
+		case 5: // offset_index_length: i32 (optional)
+			if v, ok, err := thriftI32(f.Val); err == nil && ok {
+				out.OffsetIndexLength = &v
+			} else if err != nil {
+				return ColumnChunk{}, err
+			}
+		case 6: // column_index_offset: i64 (optional)
+			if v, ok, err := thriftI64(f.Val); err == nil && ok {
+				out.ColumnIndexOffset = &v
+			} else if err != nil {
+				return ColumnChunk{}, err
+			}
+		case 7: // column_index_length: i32 (optional)
+			if v, ok, err := thriftI32(f.Val); err == nil && ok {
+				out.ColumnIndexLength = &v
+			} else if err != nil {
+				return ColumnChunk{}, err
+			}
+		default:
+			// ignore
+		}
+	}
+
+	return out, nil
+}
+
+func decodeColumnMetaData(st *kaitai_gen.ThriftCompact_CompactStruct) (*ColumnMetaData, error) {
+	fields, err := thriftFields(st)
+	if err != nil {
+		return nil, err
+	}
+
+	out := &ColumnMetaData{}
+	for _, f := range fields {
+		switch f.ID {
+		case 1: // type (enum): i32
+			if v, ok, err := thriftI32(f.Val); err == nil && ok {
+				out.Type = v
+			} else if err != nil {
+				return nil, err
+			}
+		case 2: // encodings: list<Encoding> (i32)
+			lst, ok := thriftList(f.Val)
+			if !ok || lst == nil {
+				continue
+			}
+			for _, elem := range lst.Elements {
+				if v, ok, err := thriftI32(elem); err == nil && ok {
+					out.Encodings = append(out.Encodings, v)
+				} else if err != nil {
+					return nil, err
+				}
+			}
+		case 3: // path_in_schema: list<string>
+			lst, ok := thriftList(f.Val)
+			if !ok || lst == nil {
+				continue
+			}
+			for _, elem := range lst.Elements {
+				if s, ok := thriftString(elem); ok {
+					out.PathInSchema = append(out.PathInSchema, s)
+				}
+			}
+		case 4: // codec (enum): i32
+			if v, ok, err := thriftI32(f.Val); err == nil && ok {
+				out.Codec = v
+			} else if err != nil {
+				return nil, err
+			}
+		case 5: // num_values: i64
+			if v, ok, err := thriftI64(f.Val); err == nil && ok {
+				out.NumValues = v
+			} else if err != nil {
+				return nil, err
+			}
+		case 6: // total_uncompressed_size: i64
+			if v, ok, err := thriftI64(f.Val); err == nil && ok {
+				out.TotalUncompressedSize = v
+			} else if err != nil {
+				return nil, err
+			}
+		case 7: // total_compressed_size: i64
+			if v, ok, err := thriftI64(f.Val); err == nil && ok {
+				out.TotalCompressedSize = v
+			} else if err != nil {
+				return nil, err
+			}
+		case 8: // key_value_metadata: list<KeyValue> (optional)
+			lst, ok := thriftList(f.Val)
+			if !ok || lst == nil {
+				continue
+			}
+			for _, elem := range lst.Elements {
+				kst, ok := thriftStruct(elem)
+				if !ok {
+					continue
+				}
+				kv, err := decodeKeyValue(kst)
+				if err != nil {
+					return nil, err
+				}
+				out.KeyValueMeta = append(out.KeyValueMeta, kv)
+			}
+		case 9: // data_page_offset: i64
+			if v, ok, err := thriftI64(f.Val); err == nil && ok {
+				out.DataPageOffset = v
+			} else if err != nil {
+				return nil, err
+			}
+		case 10: // index_page_offset: i64 (optional)
+			if v, ok, err := thriftI64(f.Val); err == nil && ok {
+				out.IndexPageOffset = &v
+			} else if err != nil {
+				return nil, err
+			}
+		case 11: // dictionary_page_offset: i64 (optional)
+			if v, ok, err := thriftI64(f.Val); err == nil && ok {
+				out.DictionaryPageOffset = &v
+			} else if err != nil {
+				return nil, err
+			}
+		case 12: // statistics: Statistics (optional)
+			if sst, ok := thriftStruct(f.Val); ok {
+				stats, err := decodeStatistics(sst)
+				if err != nil {
+					return nil, err
+				}
+				out.Statistics = stats
+			}
+		case 13: // encoding_stats: list<PageEncodingStats> (optional)
+			lst, ok := thriftList(f.Val)
+			if !ok || lst == nil {
+				continue
+			}
+			for _, elem := range lst.Elements {
+				est, ok := thriftStruct(elem)
+				if !ok {
+					continue
+				}
+				es, err := decodePageEncodingStats(est)
+				if err != nil {
+					return nil, err
+				}
+				out.EncodingStats = append(out.EncodingStats, es)
+			}
+		case 14: // bloom_filter_offset: i64 (optional)
+			if v, ok, err := thriftI64(f.Val); err == nil && ok {
+				out.BloomFilterOffset = &v
+			} else if err != nil {
+				return nil, err
+			}
+		case 15: // bloom_filter_length: i32 (optional)
+			if v, ok, err := thriftI32(f.Val); err == nil && ok {
+				out.BloomFilterLength = &v
+			} else if err != nil {
+				return nil, err
+			}
+		case 16: // size_statistics: SizeStatistics (optional)
+			if sst, ok := thriftStruct(f.Val); ok {
+				stats, err := decodeSizeStatistics(sst)
+				if err != nil {
+					return nil, err
+				}
+				out.SizeStatistics = stats
+			}
+		case 17: // geospatial_statistics: GeospatialStatistics (optional)
+			if sst, ok := thriftStruct(f.Val); ok {
+				stats, err := decodeGeospatialStatistics(sst)
+				if err != nil {
+					return nil, err
+				}
+				out.GeospatialStatistics = stats
+			}
+		default:
+			// ignore
+		}
+	}
+
+	return out, nil
+}
+
+func decodePageEncodingStats(st *kaitai_gen.ThriftCompact_CompactStruct) (PageEncodingStats, error) {
+	fields, err := thriftFields(st)
+	if err != nil {
+		return PageEncodingStats{}, err
+	}
+
+	var out PageEncodingStats
+	for _, f := range fields {
+		switch f.ID {
+		case 1: // page_type (enum): i32
+			if v, ok, err := thriftI32(f.Val); err == nil && ok {
+				out.PageType = v
+			} else if err != nil {
+				return PageEncodingStats{}, err
+			}
+		case 2: // encoding (enum): i32
+			if v, ok, err := thriftI32(f.Val); err == nil && ok {
+				out.Encoding = v
+			} else if err != nil {
+				return PageEncodingStats{}, err
+			}
+		case 3: // count: i32
+			if v, ok, err := thriftI32(f.Val); err == nil && ok {
+				out.Count = v
+			} else if err != nil {
+				return PageEncodingStats{}, err
+			}
+		default:
+			// ignore
+		}
+	}
+
+	return out, nil
+}
+
+// thriftI64List reads a list<i64> field.
+func thriftI64List(v *kaitai_gen.ThriftCompact_CompactValue) ([]int64, error) {
+	lst, ok := thriftList(v)
+	if !ok || lst == nil {
+		return nil, nil
+	}
+	out := make([]int64, 0, len(lst.Elements))
+	for _, elem := range lst.Elements {
+		if x, ok, err := thriftI64(elem); err == nil && ok {
+			out = append(out, x)
+		} else if err != nil {
+			return nil, err
+		}
+	}
+	return out, nil
+}
+
+func decodeSizeStatistics(st *kaitai_gen.ThriftCompact_CompactStruct) (*SizeStatistics, error) {
+	fields, err := thriftFields(st)
+	if err != nil {
+		return nil, err
+	}
+
+	out := &SizeStatistics{}
+	for _, f := range fields {
+		switch f.ID {
+		case 1: // unencoded_byte_array_data_bytes: i64 (optional)
+			if v, ok, err := thriftI64(f.Val); err == nil && ok {
+				out.UnencodedByteArrayDataBytes = &v
+			} else if err != nil {
+				return nil, err
+			}
+		case 2: // repetition_level_histogram: list<i64> (optional)
+			if out.RepetitionLevelHistogram, err = thriftI64List(f.Val); err != nil {
+				return nil, err
+			}
+		case 3: // definition_level_histogram: list<i64> (optional)
+			if out.DefinitionLevelHistogram, err = thriftI64List(f.Val); err != nil {
+				return nil, err
+			}
+		default:
+			// ignore
+		}
+	}
+
+	return out, nil
+}
+
+func decodeGeospatialStatistics(st *kaitai_gen.ThriftCompact_CompactStruct) (*GeospatialStatistics, error) {
+	fields, err := thriftFields(st)
+	if err != nil {
+		return nil, err
+	}
+
+	out := &GeospatialStatistics{}
+	for _, f := range fields {
+		switch f.ID {
+		case 1: // bbox: BoundingBox (optional)
+			if sst, ok := thriftStruct(f.Val); ok {
+				bbox, err := decodeBoundingBox(sst)
+				if err != nil {
+					return nil, err
+				}
+				out.BBox = bbox
+			}
+		case 2: // geospatial_types: list<i32> (optional)
+			lst, ok := thriftList(f.Val)
+			if !ok || lst == nil {
+				continue
+			}
+			for _, elem := range lst.Elements {
+				if v, ok, err := thriftI32(elem); err == nil && ok {
+					out.GeospatialTypes = append(out.GeospatialTypes, v)
+				} else if err != nil {
+					return nil, err
+				}
+			}
+		default:
+			// ignore
+		}
+	}
+
+	return out, nil
+}
+
+func decodeBoundingBox(st *kaitai_gen.ThriftCompact_CompactStruct) (*BoundingBox, error) {
+	fields, err := thriftFields(st)
+	if err != nil {
+		return nil, err
+	}
+
+	out := &BoundingBox{}
+	for _, f := range fields {
+		v, ok := thriftDouble(f)
+		if !ok {
+			continue
+		}
+		switch f.ID {
+		case 1: // xmin: double
+			out.XMin = v
+		case 2: // xmax: double
+			out.XMax = v
+		case 3: // ymin: double
+			out.YMin = v
+		case 4: // ymax: double
+			out.YMax = v
+		case 5: // zmin: double (optional)
+			out.ZMin = &v
+		case 6: // zmax: double (optional)
+			out.ZMax = &v
+		case 7: // mmin: double (optional)
+			out.MMin = &v
+		case 8: // mmax: double (optional)
+			out.MMax = &v
+		default:
+			// ignore
+		}
+	}
+
+	return out, nil
+}
+
+// decodePageHeader converts the Thrift Compact AST of a PageHeader.
+func decodePageHeader(st *kaitai_gen.ThriftCompact_CompactStruct) (*PageHeader, error) {
+	fields, err := thriftFields(st)
+	if err != nil {
+		return nil, err
+	}
+
+	out := &PageHeader{}
+	for _, f := range fields {
+		switch f.ID {
+		case 1: // type (enum): i32
+			if v, ok, err := thriftI32(f.Val); err == nil && ok {
+				out.Type = v
+			} else if err != nil {
+				return nil, err
+			}
+		case 2: // uncompressed_page_size: i32
+			if v, ok, err := thriftI32(f.Val); err == nil && ok {
+				out.UncompressedPageSize = v
+			} else if err != nil {
+				return nil, err
+			}
+		case 3: // compressed_page_size: i32
+			if v, ok, err := thriftI32(f.Val); err == nil && ok {
+				out.CompressedPageSize = v
+			} else if err != nil {
+				return nil, err
+			}
+		case 4: // crc: i32 (optional)
+			if v, ok, err := thriftI32(f.Val); err == nil && ok {
+				out.CRC = &v
+			} else if err != nil {
+				return nil, err
+			}
+		case 5: // data_page_header: DataPageHeader (optional)
+			if sst, ok := thriftStruct(f.Val); ok {
+				h, err := decodeDataPageHeader(sst)
+				if err != nil {
+					return nil, err
+				}
+				out.DataPageHeader = h
+			}
+		case 6: // index_page_header: IndexPageHeader (optional)
+			if _, ok := thriftStruct(f.Val); ok {
+				out.IndexPageHeader = &IndexPageHeader{}
+			}
+		case 7: // dictionary_page_header: DictionaryPageHeader (optional)
+			if sst, ok := thriftStruct(f.Val); ok {
+				h, err := decodeDictionaryPageHeader(sst)
+				if err != nil {
+					return nil, err
+				}
+				out.DictionaryPageHeader = h
+			}
+		case 8: // data_page_header_v2: DataPageHeaderV2 (optional)
+			if sst, ok := thriftStruct(f.Val); ok {
+				h, err := decodeDataPageHeaderV2(sst)
+				if err != nil {
+					return nil, err
+				}
+				out.DataPageHeaderV2 = h
+			}
+		default:
+			// ignore
+		}
+	}
+
+	return out, nil
+}
+
+func decodeDataPageHeader(st *kaitai_gen.ThriftCompact_CompactStruct) (*DataPageHeader, error) {
+	fields, err := thriftFields(st)
+	if err != nil {
+		return nil, err
+	}
+
+	out := &DataPageHeader{}
+	for _, f := range fields {
+		switch f.ID {
+		case 1: // num_values: i32
+			if v, ok, err := thriftI32(f.Val); err == nil && ok {
+				out.NumValues = v
+			} else if err != nil {
+				return nil, err
+			}
+		case 2: // encoding (enum): i32
+			if v, ok, err := thriftI32(f.Val); err == nil && ok {
+				out.Encoding = v
+			} else if err != nil {
+				return nil, err
+			}
+		case 3: // definition_level_encoding (enum): i32
+			if v, ok, err := thriftI32(f.Val); err == nil && ok {
+				out.DefinitionLevelEncoding = v
+			} else if err != nil {
+				return nil, err
+			}
+		case 4: // repetition_level_encoding (enum): i32
+			if v, ok, err := thriftI32(f.Val); err == nil && ok {
+				out.RepetitionLevelEncoding = v
+			} else if err != nil {
+				return nil, err
+			}
+		case 5: // statistics: Statistics (optional)
+			if sst, ok := thriftStruct(f.Val); ok {
+				stats, err := decodeStatistics(sst)
+				if err != nil {
+					return nil, err
+				}
+				out.Statistics = stats
+			}
+		default:
+			// ignore
+		}
+	}
+
+	return out, nil
+}
+
+func decodeDictionaryPageHeader(st *kaitai_gen.ThriftCompact_CompactStruct) (*DictionaryPageHeader, error) {
+	fields, err := thriftFields(st)
+	if err != nil {
+		return nil, err
+	}
+
+	out := &DictionaryPageHeader{}
+	for _, f := range fields {
+		switch f.ID {
+		case 1: // num_values: i32
+			if v, ok, err := thriftI32(f.Val); err == nil && ok {
+				out.NumValues = v
+			} else if err != nil {
+				return nil, err
+			}
+		case 2: // encoding (enum): i32
+			if v, ok, err := thriftI32(f.Val); err == nil && ok {
+				out.Encoding = v
+			} else if err != nil {
+				return nil, err
+			}
+		case 3: // is_sorted: bool (optional)
+			if v, ok := thriftBool(f); ok {
+				out.IsSorted = &v
+			}
+		default:
+			// ignore
+		}
+	}
+
+	return out, nil
+}
+
+func decodeDataPageHeaderV2(st *kaitai_gen.ThriftCompact_CompactStruct) (*DataPageHeaderV2, error) {
+	fields, err := thriftFields(st)
+	if err != nil {
+		return nil, err
+	}
+
+	// is_compressed defaults to true when absent.
+	out := &DataPageHeaderV2{IsCompressed: true}
+	for _, f := range fields {
+		switch f.ID {
+		case 1: // num_values: i32
+			if v, ok, err := thriftI32(f.Val); err == nil && ok {
+				out.NumValues = v
+			} else if err != nil {
+				return nil, err
+			}
+		case 2: // num_nulls: i32
+			if v, ok, err := thriftI32(f.Val); err == nil && ok {
+				out.NumNulls = v
+			} else if err != nil {
+				return nil, err
+			}
+		case 3: // num_rows: i32
+			if v, ok, err := thriftI32(f.Val); err == nil && ok {
+				out.NumRows = v
+			} else if err != nil {
+				return nil, err
+			}
+		case 4: // encoding (enum): i32
+			if v, ok, err := thriftI32(f.Val); err == nil && ok {
+				out.Encoding = v
+			} else if err != nil {
+				return nil, err
+			}
+		case 5: // definition_levels_byte_length: i32
+			if v, ok, err := thriftI32(f.Val); err == nil && ok {
+				out.DefinitionLevelsByteLength = v
+			} else if err != nil {
+				return nil, err
+			}
+		case 6: // repetition_levels_byte_length: i32
+			if v, ok, err := thriftI32(f.Val); err == nil && ok {
+				out.RepetitionLevelsByteLength = v
+			} else if err != nil {
+				return nil, err
+			}
+		case 7: // is_compressed: bool (optional)
+			if v, ok := thriftBool(f); ok {
+				out.IsCompressed = v
+			}
+		case 8: // statistics: Statistics (optional)
+			if sst, ok := thriftStruct(f.Val); ok {
+				stats, err := decodeStatistics(sst)
+				if err != nil {
+					return nil, err
+				}
+				out.Statistics = stats
+			}
+		default:
+			// ignore
+		}
+	}
+
+	return out, nil
+}
+
+func decodeStatistics(st *kaitai_gen.ThriftCompact_CompactStruct) (*Statistics, error) {
+	fields, err := thriftFields(st)
+	if err != nil {
+		return nil, err
+	}
+
+	out := &Statistics{}
+	for _, f := range fields {
+		switch f.ID {
+		case 1: // max: binary (optional, deprecated)
+			if s, ok := thriftString(f.Val); ok {
+				out.Max = []byte(s)
+			}
+		case 2: // min: binary (optional, deprecated)
+			if s, ok := thriftString(f.Val); ok {
+				out.Min = []byte(s)
+			}
+		case 3: // null_count: i64 (optional)
+			if v, ok, err := thriftI64(f.Val); err == nil && ok {
+				out.NullCount = &v
+			} else if err != nil {
+				return nil, err
+			}
+		case 4: // distinct_count: i64 (optional)
+			if v, ok, err := thriftI64(f.Val); err == nil && ok {
+				out.DistinctCount = &v
+			} else if err != nil {
+				return nil, err
+			}
+		case 5: // max_value: binary (optional)
+			if s, ok := thriftString(f.Val); ok {
+				out.MaxValue = []byte(s)
+			}
+		case 6: // min_value: binary (optional)
+			if s, ok := thriftString(f.Val); ok {
+				out.MinValue = []byte(s)
+			}
+		case 7: // is_max_value_exact: bool (optional)
+			if v, ok := thriftBool(f); ok {
+				out.IsMaxValueExact = &v
+			}
+		case 8: // is_min_value_exact: bool (optional)
+			if v, ok := thriftBool(f); ok {
+				out.IsMinValueExact = &v
+			}
+		default:
+			// ignore
+		}
+	}
+
+	return out, nil
+}
+
+// thriftBoolList reads a list<bool> field. Unlike struct fields, list elements
+// carry their value in a byte of their own, where 1 means true.
+func thriftBoolList(v *kaitai_gen.ThriftCompact_CompactValue) []bool {
+	lst, ok := thriftList(v)
+	if !ok || lst == nil {
+		return nil
+	}
+	out := make([]bool, 0, len(lst.Elements))
+	for _, elem := range lst.Elements {
+		out = append(out, elem.BoolValue == 1)
+	}
+	return out
+}
+
+// thriftBinaryList reads a list<binary> field.
+func thriftBinaryList(v *kaitai_gen.ThriftCompact_CompactValue) [][]byte {
+	lst, ok := thriftList(v)
+	if !ok || lst == nil {
+		return nil
+	}
+	out := make([][]byte, 0, len(lst.Elements))
+	for _, elem := range lst.Elements {
+		s, _ := thriftString(elem)
+		out = append(out, []byte(s))
+	}
+	return out
+}
+
+func decodeColumnIndex(st *kaitai_gen.ThriftCompact_CompactStruct) (*ColumnIndex, error) {
+	fields, err := thriftFields(st)
+	if err != nil {
+		return nil, err
+	}
+
+	out := &ColumnIndex{}
+	for _, f := range fields {
+		switch f.ID {
+		case 1: // null_pages: list<bool>
+			out.NullPages = thriftBoolList(f.Val)
+		case 2: // min_values: list<binary>
+			out.MinValues = thriftBinaryList(f.Val)
+		case 3: // max_values: list<binary>
+			out.MaxValues = thriftBinaryList(f.Val)
+		case 4: // boundary_order (enum): i32
+			if v, ok, err := thriftI32(f.Val); err == nil && ok {
+				out.BoundaryOrder = v
+			} else if err != nil {
+				return nil, err
+			}
+		case 5: // null_counts: list<i64> (optional)
+			if out.NullCounts, err = thriftI64List(f.Val); err != nil {
+				return nil, err
+			}
+		case 6: // repetition_level_histograms: list<i64> (optional)
+			if out.RepetitionLevelHistograms, err = thriftI64List(f.Val); err != nil {
+				return nil, err
+			}
+		case 7: // definition_level_histograms: list<i64> (optional)
+			if out.DefinitionLevelHistograms, err = thriftI64List(f.Val); err != nil {
+				return nil, err
+			}
+		default:
+			// ignore
+		}
+	}
+
+	return out, nil
+}
+
+func decodeOffsetIndex(st *kaitai_gen.ThriftCompact_CompactStruct) (*OffsetIndex, error) {
+	fields, err := thriftFields(st)
+	if err != nil {
+		return nil, err
+	}
+
+	out := &OffsetIndex{}
+	for _, f := range fields {
+		switch f.ID {
+		case 1: // page_locations: list<PageLocation>
+			lst, ok := thriftList(f.Val)
+			if !ok || lst == nil {
+				continue
+			}
+			for _, elem := range lst.Elements {
+				pst, ok := thriftStruct(elem)
+				if !ok {
+					continue
+				}
+				loc, err := decodePageLocation(pst)
+				if err != nil {
+					return nil, err
+				}
+				out.PageLocations = append(out.PageLocations, loc)
+			}
+		case 2: // unencoded_byte_array_data_bytes: list<i64> (optional)
+			if out.UnencodedByteArrayDataBytes, err = thriftI64List(f.Val); err != nil {
+				return nil, err
+			}
+		default:
+			// ignore
+		}
+	}
+
+	return out, nil
+}
+
+func decodePageLocation(st *kaitai_gen.ThriftCompact_CompactStruct) (PageLocation, error) {
+	fields, err := thriftFields(st)
+	if err != nil {
+		return PageLocation{}, err
+	}
+
+	var out PageLocation
+	for _, f := range fields {
+		switch f.ID {
+		case 1: // offset: i64
+			if v, ok, err := thriftI64(f.Val); err == nil && ok {
+				out.Offset = v
+			} else if err != nil {
+				return PageLocation{}, err
+			}
+		case 2: // compressed_page_size: i32
+			if v, ok, err := thriftI32(f.Val); err == nil && ok {
+				out.CompressedPageSize = v
+			} else if err != nil {
+				return PageLocation{}, err
+			}
+		case 3: // first_row_index: i64
+			if v, ok, err := thriftI64(f.Val); err == nil && ok {
+				out.FirstRowIndex = v
+			} else if err != nil {
+				return PageLocation{}, err
+			}
+		default:
+			// ignore
+		}
+	}
+
+	return out, nil
+}
+
+func decodeBloomFilterHeader(st *kaitai_gen.ThriftCompact_CompactStruct) (*BloomFilterHeader, error) {
+	fields, err := thriftFields(st)
+	if err != nil {
+		return nil, err
+	}
+
+	out := &BloomFilterHeader{}
+	for _, f := range fields {
+		switch f.ID {
+		case 1: // numBytes: i32
+			if v, ok, err := thriftI32(f.Val); err == nil && ok {
+				out.NumBytes = v
+			} else if err != nil {
+				return nil, err
+			}
+		case 2: // algorithm: BloomFilterAlgorithm (union; 1: BLOCK)
+			member, err := thriftUnionMember(f.Val)
+			if err != nil {
+				return nil, err
+			}
+			out.Algorithm.Block = member == 1
+		case 3: // hash: BloomFilterHash (union; 1: XXHASH)
+			member, err := thriftUnionMember(f.Val)
+			if err != nil {
+				return nil, err
+			}
+			out.Hash.XxHash = member == 1
+		case 4: // compression: BloomFilterCompression (union; 1: UNCOMPRESSED)
+			member, err := thriftUnionMember(f.Val)
+			if err != nil {
+				return nil, err
+			}
+			out.Compression.Uncompressed = member == 1
+		default:
+			// ignore
+		}
+	}
+
+	return out, nil
+}
+
+// thriftUnionMember returns the field ID of the member set in a union of
+// empty structs, or 0 if none is.
+func thriftUnionMember(v *kaitai_gen.ThriftCompact_CompactValue) (int16, error) {
+	st, ok := thriftStruct(v)
+	if !ok {
+		return 0, nil
+	}
+	fields, err := thriftFields(st)
+	if err != nil {
+		return 0, err
+	}
+	if len(fields) == 0 {
+		return 0, nil
+	}
+	return fields[0].ID, nil
+}
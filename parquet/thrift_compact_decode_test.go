@@ -0,0 +1,396 @@
+package parquet
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/binary"
+	"reflect"
+	"testing"
+
+	"kaitai_parquet/kaitai_gen"
+)
+
+// compactWriter writes the Thrift compact protocol, enough to build the
+// structures of test files.
+type compactWriter struct {
+	bytes.Buffer
+	lastIDs []int16
+}
+
+func (w *compactWriter) begin() { w.lastIDs = append(w.lastIDs, 0) }
+
+func (w *compactWriter) end() {
+	w.WriteByte(0) // STOP
+	w.lastIDs = w.lastIDs[:len(w.lastIDs)-1]
+}
+
+func (w *compactWriter) uvarint(v uint64) { w.Write(binary.AppendUvarint(nil, v)) }
+
+// varint writes a zigzag varint, as binary.AppendVarint encodes them.
+func (w *compactWriter) varint(v int64) { w.Write(binary.AppendVarint(nil, v)) }
+
+func (w *compactWriter) field(id int16, typ byte) {
+	last := &w.lastIDs[len(w.lastIDs)-1]
+	if delta := id - *last; delta > 0 && delta <= 15 {
+		w.WriteByte(byte(delta)<<4 | typ)
+	} else {
+		w.WriteByte(typ)
+		w.varint(int64(id))
+	}
+	*last = id
+}
+
+// boolean writes a bool field, whose value is its type: 1 true, 2 false.
+func (w *compactWriter) boolean(id int16, v bool) {
+	if v {
+		w.field(id, 1)
+	} else {
+		w.field(id, 2)
+	}
+}
+
+func (w *compactWriter) i8(id int16, v int8)   { w.field(id, 3); w.WriteByte(byte(v)) }
+func (w *compactWriter) i32(id int16, v int32) { w.field(id, 5); w.varint(int64(v)) }
+func (w *compactWriter) i64(id int16, v int64) { w.field(id, 6); w.varint(v) }
+
+func (w *compactWriter) binary(id int16, b []byte) {
+	w.field(id, 8)
+	w.elemBinary(b)
+}
+
+func (w *compactWriter) elemBinary(b []byte) {
+	w.uvarint(uint64(len(b)))
+	w.Write(b)
+}
+
+func (w *compactWriter) structField(id int16, fields func()) {
+	w.field(id, 12)
+	w.elemStruct(fields)
+}
+
+func (w *compactWriter) elemStruct(fields func()) {
+	w.begin()
+	fields()
+	w.end()
+}
+
+func (w *compactWriter) list(id int16, elemType byte, n int, elems func()) {
+	w.field(id, 9)
+	if n < 15 {
+		w.WriteByte(byte(n)<<4 | elemType)
+	} else {
+		w.WriteByte(0xf0 | elemType)
+		w.uvarint(uint64(n))
+	}
+	elems()
+}
+
+func ptr[T any](v T) *T { return &v }
+
+// compactStruct writes a struct with the given fields and parses it back.
+func compactStruct(t *testing.T, fields func(w *compactWriter)) *kaitai_gen.ThriftCompact_CompactStruct {
+	t.Helper()
+	var w compactWriter
+	w.begin()
+	fields(&w)
+	w.end()
+	st, n, err := parseCompactStructFromBufio(bufio.NewReader(bytes.NewReader(w.Bytes())), 0)
+	if err != nil {
+		t.Fatalf("parsing struct: %v", err)
+	}
+	if n != w.Len() {
+		t.Fatalf("parsed %d of %d bytes", n, w.Len())
+	}
+	return st
+}
+
+func TestDecodeDataPageHeaderV2(t *testing.T) {
+	fields := func(w *compactWriter) {
+		w.i32(1, 10) // num_values
+		w.i32(2, 3)  // num_nulls
+		w.i32(3, 8)  // num_rows
+		w.i32(4, 8)  // encoding: RLE_DICTIONARY
+		w.i32(5, 4)  // definition_levels_byte_length
+		w.i32(6, 2)  // repetition_levels_byte_length
+	}
+	want := DataPageHeaderV2{
+		NumValues: 10, NumNulls: 3, NumRows: 8, Encoding: 8,
+		DefinitionLevelsByteLength: 4, RepetitionLevelsByteLength: 2,
+		IsCompressed: true,
+	}
+
+	got, err := decodeDataPageHeaderV2(compactStruct(t, fields))
+	if err != nil {
+		t.Fatalf("decodeDataPageHeaderV2: %v", err)
+	}
+	if *got != want {
+		t.Errorf("without is_compressed: got %+v, want %+v", got, want)
+	}
+
+	got, err = decodeDataPageHeaderV2(compactStruct(t, func(w *compactWriter) {
+		fields(w)
+		w.boolean(7, false)
+	}))
+	if err != nil {
+		t.Fatalf("decodeDataPageHeaderV2: %v", err)
+	}
+	want.IsCompressed = false
+	if *got != want {
+		t.Errorf("is_compressed false: got %+v, want %+v", got, want)
+	}
+}
+
+func TestDecodePageHeader(t *testing.T) {
+	tests := []struct {
+		name   string
+		fields func(w *compactWriter)
+		want   PageHeader
+	}{
+		{
+			name: "data page",
+			fields: func(w *compactWriter) {
+				w.i32(1, 0)   // type: DATA_PAGE
+				w.i32(2, 100) // uncompressed_page_size
+				w.i32(3, 60)  // compressed_page_size
+				w.i32(4, -7)  // crc
+				w.structField(5, func() {
+					w.i32(1, 12) // num_values
+					w.i32(2, 8)  // encoding: RLE_DICTIONARY
+					w.i32(3, 3)  // definition_level_encoding: RLE
+					w.i32(4, 4)  // repetition_level_encoding: BIT_PACKED
+					w.structField(5, func() {
+						w.i64(3, 2) // statistics.null_count
+					})
+				})
+			},
+			want: PageHeader{
+				Type: 0, UncompressedPageSize: 100, CompressedPageSize: 60, CRC: ptr(int32(-7)),
+				DataPageHeader: &DataPageHeader{
+					NumValues: 12, Encoding: 8, DefinitionLevelEncoding: 3, RepetitionLevelEncoding: 4,
+					Statistics: &Statistics{NullCount: ptr(int64(2))},
+				},
+			},
+		},
+		{
+			name: "dictionary page",
+			fields: func(w *compactWriter) {
+				w.i32(1, 2) // type: DICTIONARY_PAGE
+				w.i32(2, 40)
+				w.i32(3, 40)
+				w.structField(7, func() {
+					w.i32(1, 10) // num_values
+					w.i32(2, 0)  // encoding: PLAIN
+					w.boolean(3, true)
+				})
+			},
+			want: PageHeader{
+				Type: 2, UncompressedPageSize: 40, CompressedPageSize: 40,
+				DictionaryPageHeader: &DictionaryPageHeader{NumValues: 10, IsSorted: ptr(true)},
+			},
+		},
+		{
+			name: "data page v2",
+			fields: func(w *compactWriter) {
+				w.i32(1, 3) // type: DATA_PAGE_V2
+				w.i32(2, 30)
+				w.i32(3, 20)
+				w.structField(8, func() {
+					w.i32(1, 5) // num_values
+					w.i32(2, 1) // num_nulls
+					w.i32(3, 5) // num_rows
+					w.i32(4, 0) // encoding: PLAIN
+					w.i32(5, 2) // definition_levels_byte_length
+					w.i32(6, 0) // repetition_levels_byte_length
+				})
+			},
+			want: PageHeader{
+				Type: 3, UncompressedPageSize: 30, CompressedPageSize: 20,
+				DataPageHeaderV2: &DataPageHeaderV2{
+					NumValues: 5, NumNulls: 1, NumRows: 5, DefinitionLevelsByteLength: 2, IsCompressed: true,
+				},
+			},
+		},
+		{
+			name: "index page and unknown fields",
+			fields: func(w *compactWriter) {
+				w.i32(1, 1) // type: INDEX_PAGE
+				w.i32(2, 0)
+				w.i32(3, 0)
+				w.structField(6, func() {})
+				w.binary(20, []byte("future field"))
+			},
+			want: PageHeader{Type: 1, IndexPageHeader: &IndexPageHeader{}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := decodePageHeader(compactStruct(t, tt.fields))
+			if err != nil {
+				t.Fatalf("decodePageHeader: %v", err)
+			}
+			if !reflect.DeepEqual(*got, tt.want) {
+				t.Errorf("got %+v, want %+v", *got, tt.want)
+			}
+		})
+	}
+}
+
+func TestDecodeFileMetaData(t *testing.T) {
+	st := compactStruct(t, func(w *compactWriter) {
+		w.i32(1, 2)               // version
+		w.list(2, 12, 2, func() { // schema
+			w.elemStruct(func() {
+				w.binary(4, []byte("schema"))
+				w.i32(5, 1) // num_children
+			})
+			w.elemStruct(func() {
+				w.i32(1, 1) // type: INT32
+				w.i32(3, 1) // repetition_type: OPTIONAL
+				w.binary(4, []byte("a"))
+			})
+		})
+		w.i64(3, 7)               // num_rows
+		w.list(4, 12, 1, func() { // row_groups
+			w.elemStruct(func() {
+				w.list(1, 12, 1, func() { // columns
+					w.elemStruct(func() {
+						w.i64(2, 4) // file_offset
+						w.structField(3, func() {
+							w.i32(1, 1)                                          // type: INT32
+							w.list(2, 5, 2, func() { w.varint(0); w.varint(3) }) // encodings: PLAIN, RLE
+							w.list(3, 8, 1, func() { w.elemBinary([]byte("a")) })
+							w.i32(4, 1)  // codec: SNAPPY
+							w.i64(5, 7)  // num_values
+							w.i64(6, 40) // total_uncompressed_size
+							w.i64(7, 30) // total_compressed_size
+							w.i64(9, 4)  // data_page_offset
+						})
+					})
+				})
+				w.i64(2, 40) // total_byte_size
+				w.i64(3, 7)  // num_rows
+			})
+		})
+		w.list(5, 12, 2, func() { // key_value_metadata
+			w.elemStruct(func() {
+				w.binary(1, []byte("writer"))
+				w.binary(2, []byte("test"))
+			})
+			w.elemStruct(func() {
+				w.binary(1, []byte("flag"))
+			})
+		})
+		w.binary(6, []byte("parquet-test version 1")) // created_by
+		w.list(7, 12, 2, func() {                     // column_orders
+			w.elemStruct(func() { w.structField(1, func() {}) }) // TYPE_ORDER
+			w.elemStruct(func() { w.structField(2, func() {}) }) // a future member
+		})
+		w.structField(8, func() { // encryption_algorithm
+			w.structField(1, func() { // AES_GCM_V1
+				w.binary(1, []byte("prefix"))
+				w.boolean(3, true)
+			})
+		})
+		w.binary(9, []byte{0xde, 0xad}) // footer_signing_key_metadata
+	})
+
+	got, err := decodeFileMetaData(st)
+	if err != nil {
+		t.Fatalf("decodeFileMetaData: %v", err)
+	}
+	want := &FileMetadata{
+		Version: 2,
+		Schema: []SchemaElement{
+			{Name: "schema", NumChildren: ptr(int32(1))},
+			{Type: 1, RepetitionType: ptr(int32(1)), Name: "a"},
+		},
+		NumRows: 7,
+		RowGroups: []RowGroup{{
+			Columns: []ColumnChunk{{
+				FileOffset: 4,
+				MetaData: &ColumnMetaData{
+					Type: 1, Encodings: []int32{0, 3}, PathInSchema: []string{"a"}, Codec: 1,
+					NumValues: 7, TotalUncompressedSize: 40, TotalCompressedSize: 30, DataPageOffset: 4,
+				},
+			}},
+			TotalByteSize: 40,
+			NumRows:       7,
+		}},
+		KeyValueMetadata: []KeyValue{{Key: "writer", Value: ptr("test")}, {Key: "flag"}},
+		CreatedBy:        ptr("parquet-test version 1"),
+		ColumnOrders:     []ColumnOrder{{TypeDefinedOrder: true}, {}},
+		EncryptionAlgorithm: &EncryptionAlgorithm{
+			AesGcmV1: &AesGcm{AADPrefix: []byte("prefix"), SupplyAADPrefix: ptr(true)},
+		},
+		FooterSigningKeyMetadata: []byte{0xde, 0xad},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("got  %+v\nwant %+v", got, want)
+	}
+
+	if v, ok := got.KeyValue("writer"); v != "test" || !ok {
+		t.Errorf("KeyValue(writer) = %q, %v; want test, true", v, ok)
+	}
+	if v, ok := got.KeyValue("flag"); v != "" || !ok {
+		t.Errorf("KeyValue(flag) = %q, %v; want \"\", true", v, ok)
+	}
+	if _, ok := got.KeyValue("missing"); ok {
+		t.Error("KeyValue(missing) reported a value")
+	}
+}
+
+func TestDecodeLogicalType(t *testing.T) {
+	unit := func(w *compactWriter, id int16) {
+		w.structField(2, func() { w.structField(id, func() {}) })
+	}
+	tests := []struct {
+		name   string
+		fields func(w *compactWriter)
+		want   *LogicalType
+		str    string
+	}{
+		{"string", func(w *compactWriter) { w.structField(1, func() {}) },
+			&LogicalType{Kind: LogicalTypeString}, "STRING"},
+		{"decimal", func(w *compactWriter) {
+			w.structField(5, func() { w.i32(1, 2); w.i32(2, 10) })
+		}, &LogicalType{Kind: LogicalTypeDecimal, Decimal: &DecimalType{Scale: 2, Precision: 10}}, "DECIMAL(10,2)"},
+		{"time", func(w *compactWriter) {
+			w.structField(7, func() { w.boolean(1, false); unit(w, 1) })
+		}, &LogicalType{Kind: LogicalTypeTime, Time: &TimeType{Unit: TimeUnitMillis}}, "TIME(MILLIS,local)"},
+		{"timestamp", func(w *compactWriter) {
+			w.structField(8, func() { w.boolean(1, true); unit(w, 3) })
+		}, &LogicalType{Kind: LogicalTypeTimestamp, Timestamp: &TimestampType{IsAdjustedToUTC: true, Unit: TimeUnitNanos}}, "TIMESTAMP(NANOS,UTC)"},
+		{"integer", func(w *compactWriter) {
+			w.structField(10, func() { w.i8(1, 16); w.boolean(2, false) })
+		}, &LogicalType{Kind: LogicalTypeInteger, Integer: &IntType{BitWidth: 16}}, "INTEGER(16,unsigned)"},
+		{"unknown", func(w *compactWriter) { w.structField(11, func() {}) },
+			&LogicalType{Kind: LogicalTypeUnknown}, "UNKNOWN"},
+		{"uuid", func(w *compactWriter) { w.structField(14, func() {}) },
+			&LogicalType{Kind: LogicalTypeUUID}, "UUID"},
+		{"variant", func(w *compactWriter) {
+			w.structField(16, func() { w.i8(1, 1) })
+		}, &LogicalType{Kind: LogicalTypeVariant, Variant: &VariantType{SpecificationVersion: ptr(int8(1))}}, "VARIANT"},
+		{"geometry", func(w *compactWriter) {
+			w.structField(17, func() { w.binary(1, []byte("OGC:CRS84")) })
+		}, &LogicalType{Kind: LogicalTypeGeometry, Geometry: &GeometryType{CRS: ptr("OGC:CRS84")}}, "GEOMETRY(OGC:CRS84)"},
+		{"geography", func(w *compactWriter) {
+			w.structField(18, func() { w.i32(2, 4) })
+		}, &LogicalType{Kind: LogicalTypeGeography, Geography: &GeographyType{Algorithm: ptr(int32(4))}}, "GEOGRAPHY"},
+		// A member added after this reader was written is not an error.
+		{"future member", func(w *compactWriter) { w.structField(30, func() {}) }, nil, ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := decodeLogicalType(compactStruct(t, tt.fields))
+			if err != nil {
+				t.Fatalf("decodeLogicalType: %v", err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Fatalf("got %+v, want %+v", got, tt.want)
+			}
+			if got != nil && got.String() != tt.str {
+				t.Errorf("String() = %q, want %q", got.String(), tt.str)
+			}
+		})
+	}
+}
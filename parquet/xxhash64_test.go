@@ -0,0 +1,26 @@
+package parquet
+
+import "testing"
+
+func TestXXHash64(t *testing.T) {
+	// Inputs cover the 32-byte stripes, the 8- and 4-byte tails and single
+	// bytes of the algorithm.
+	tests := []struct {
+		in   string
+		want uint64
+	}{
+		{"", 0xef46db3751d8e999},
+		{"a", 0xd24ec4f1a98c6e5b},
+		{"abc", 0x44bc2cf5ad770999},
+		{"message digest", 0x066ed728fceeb3be},
+		{"abcdefghijklmnopqrstuvwxyz", 0xcfe1f278fa89835c},
+		{"0123456789abcdef0123456789abcdef", 0x642a94958e71e6c5},
+		{"The quick brown fox jumps over the lazy dog", 0x0b242d361fda71bc},
+		{"12345678901234567890123456789012345678901234567890123456789012345678901234567890", 0xe04a477f19ee145d},
+	}
+	for _, tt := range tests {
+		if got := xxHash64([]byte(tt.in)); got != tt.want {
+			t.Errorf("xxHash64(%q) = %016x, want %016x", tt.in, got, tt.want)
+		}
+	}
+}